@@ -22,8 +22,9 @@ func dataSourceAwsLakeFormationDataLakeSettings() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"catalog_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAwsAccountId,
 			},
 			"create_database_default_permissions": {
 				Type:     schema.TypeList,
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/hashcode"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
 )
 
 func dataSourceAwsLakeFormationPermissions() *schema.Resource {
@@ -71,14 +72,14 @@ func dataSourceAwsLakeFormationPermissions() *schema.Resource {
 				},
 			},
 			"permissions": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			"permissions_with_grant_option": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -156,6 +157,11 @@ func dataSourceAwsLakeFormationPermissions() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						"wildcard": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
 					},
 				},
 			},
@@ -166,6 +172,10 @@ func dataSourceAwsLakeFormationPermissions() *schema.Resource {
 func dataSourceAwsLakeFormationPermissionsRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).lakeformationconn
 
+	if err := lakeFormationValidateResourceBlocks(d.Get("catalog_resource").(bool), d.Get("data_location").([]interface{}), d.Get("database").([]interface{}), nil, d.Get("table").([]interface{}), d.Get("table_with_columns").([]interface{})); err != nil {
+		return err
+	}
+
 	input := &lakeformation.ListPermissionsInput{
 		Principal: &lakeformation.DataLakePrincipal{
 			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
@@ -177,94 +187,73 @@ func dataSourceAwsLakeFormationPermissionsRead(d *schema.ResourceData, meta inte
 	}
 
 	input.Resource = expandLakeFormationResource(d, true)
+	input.ResourceType = aws.String(tflakeformation.ListPermissionsResourceType(expandLakeFormationResourceType(d)))
 	matchResource := expandLakeFormationResource(d, false)
+	// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
+	selectPermissionsResource := expandLakeFormationResourceForSelectPermissions(d)
 
-	log.Printf("[DEBUG] Reading Lake Formation permissions: %v", input)
-	var principalResourcePermissions []*lakeformation.PrincipalResourcePermissions
+	grantorCatalogId := meta.(*AWSClient).accountid
+	if input.CatalogId != nil {
+		grantorCatalogId = aws.StringValue(input.CatalogId)
+	}
 
-	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
-		err := conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
-			for _, permission := range resp.PrincipalResourcePermissions {
-				if permission == nil {
-					continue
-				}
+	log.Printf("[DEBUG] Reading Lake Formation permissions: %v", input)
+	match := func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch(d.Get("principal").(string), matchResource, selectPermissionsResource, grantorCatalogId, permission)
+	}
+	collector := newLakeFormationPermissionsCollector(match)
 
-				if resourceAwsLakeFormationPermissionsCompareResource(*matchResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-				}
-			}
-			return !lastPage
-		})
-
-		if err != nil {
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
-				return resource.RetryableError(err)
-			}
-			return resource.NonRetryableError(fmt.Errorf("error reading Lake Formation Permissions: %w", err))
-		}
-		return nil
-	})
+	err := resource.Retry(2*time.Minute, lakeFormationRetryFunc(isLakeFormationListPermissionsRetryableError, func() error {
+		collector = newLakeFormationPermissionsCollector(match)
+		return conn.ListPermissionsPages(input, collector.page)
+	}))
 
 	if isResourceTimeoutError(err) {
-		err = conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
-			for _, permission := range resp.PrincipalResourcePermissions {
-				if permission == nil {
-					continue
-				}
-
-				if resourceAwsLakeFormationPermissionsCompareResource(*matchResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-				}
-			}
-			return !lastPage
-		})
+		collector = newLakeFormationPermissionsCollector(match)
+		err = conn.ListPermissionsPages(input, collector.page)
 	}
 
-	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
-		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+	// The principal holding no permissions on the resource is a valid result, not an error.
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		log.Printf("[DEBUG] Lake Formation permissions for principal (%s) not found", d.Get("principal").(string))
+		err = nil
 	}
 
 	if err != nil {
 		return fmt.Errorf("error reading Lake Formation permissions: %w", err)
 	}
 
-	if len(principalResourcePermissions) > 1 {
-		return fmt.Errorf("error reading Lake Formation permissions: %s", "multiple permissions found")
-	}
+	principalResourcePermissions := tflakeformation.PermissionsAggregate(collector.matches)
 
 	d.SetId(fmt.Sprintf("%d", hashcode.String(input.String())))
-	for _, permissions := range principalResourcePermissions {
-		d.Set("principal", permissions.Principal.DataLakePrincipalIdentifier)
-		d.Set("permissions", permissions.Permissions)
-		d.Set("permissions_with_grant_option", permissions.PermissionsWithGrantOption)
+	d.Set("permissions", flattenStringSet(aws.StringSlice(flattenLakeFormationPermissions(principalResourcePermissions))))
+	d.Set("permissions_with_grant_option", flattenStringSet(aws.StringSlice(flattenLakeFormationGrantPermissions(principalResourcePermissions))))
 
-		if permissions.Resource.Catalog != nil {
-			d.Set("catalog_resource", true)
-		}
+	if len(principalResourcePermissions) == 0 {
+		return nil
+	}
 
-		if permissions.Resource.DataLocation != nil {
-			d.Set("data_location", []interface{}{flattenLakeFormationDataLocationResource(permissions.Resource.DataLocation)})
-		} else {
-			d.Set("data_location", nil)
-		}
+	if principalResourcePermissions[0].Resource.Catalog != nil {
+		d.Set("catalog_resource", true)
+	}
 
-		if permissions.Resource.Database != nil {
-			d.Set("database", []interface{}{flattenLakeFormationDatabaseResource(permissions.Resource.Database)})
-		} else {
-			d.Set("database", nil)
-		}
+	if principalResourcePermissions[0].Resource.DataLocation != nil {
+		d.Set("data_location", []interface{}{flattenLakeFormationDataLocationResource(principalResourcePermissions[0].Resource.DataLocation)})
+	}
+
+	if principalResourcePermissions[0].Resource.Database != nil {
+		d.Set("database", []interface{}{flattenLakeFormationDatabaseResource(principalResourcePermissions[0].Resource.Database)})
+	}
+
+	// a SELECT grant on a table also matches the table with columns entry created for it
+	tableBlock, tableWithColumnsBlock := flattenLakeFormationPermissionsTableBlocks(expandLakeFormationResourceType(d), principalResourcePermissions)
+
+	if tableBlock != nil {
+		d.Set("table", tableBlock)
+	}
 
-		// table with columns permissions will include the table and table with columns
-		if permissions.Resource.TableWithColumns != nil {
-			d.Set("table_with_columns", []interface{}{flattenLakeFormationTableWithColumnsResource(permissions.Resource.TableWithColumns)})
-		} else if permissions.Resource.Table != nil {
-			d.Set("table_with_columns", nil)
-			d.Set("table", []interface{}{flattenLakeFormationTableResource(permissions.Resource.Table)})
-		} else {
-			d.Set("table", nil)
-		}
+	if tableWithColumnsBlock != nil {
+		d.Set("table_with_columns", tableWithColumnsBlock)
 	}
 
 	return nil
@@ -0,0 +1,272 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func dataSourceAwsLakeFormationPrincipalPermissions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsLakeFormationPrincipalPermissionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"grant": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"catalog_resource": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"data_location": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"arn": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"catalog_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"database": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"catalog_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"permissions": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"permissions_with_grant_option": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"table": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"catalog_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"database_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"wildcard": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"table_with_columns": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"catalog_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"column_names": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"database_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"excluded_column_names": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"wildcard": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"principal": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePrincipal,
+			},
+		},
+	}
+}
+
+func dataSourceAwsLakeFormationPrincipalPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).lakeformationconn
+	principal := d.Get("principal").(string)
+
+	input := &lakeformation.ListPermissionsInput{
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(principal),
+		},
+	}
+
+	catalogId := meta.(*AWSClient).accountid
+	if v, ok := d.GetOk("catalog_id"); ok {
+		input.CatalogId = aws.String(v.(string))
+		catalogId = v.(string)
+	}
+
+	log.Printf("[DEBUG] Reading Lake Formation principal permissions: %v", input)
+	var principalResourcePermissions []*lakeformation.PrincipalResourcePermissions
+
+	err := resource.Retry(2*time.Minute, lakeFormationRetryFunc(isLakeFormationListPermissionsRetryableError, func() error {
+		principalResourcePermissions = nil
+
+		return conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
+			principalResourcePermissions = append(principalResourcePermissions, resp.PrincipalResourcePermissions...)
+			return !lastPage
+		})
+	}))
+
+	if isResourceTimeoutError(err) {
+		principalResourcePermissions = nil
+
+		err = conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
+			principalResourcePermissions = append(principalResourcePermissions, resp.PrincipalResourcePermissions...)
+			return !lastPage
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading Lake Formation principal (%s) permissions: %w", principal, err)
+	}
+
+	d.SetId(principal)
+
+	if err := d.Set("grant", flattenLakeFormationPrincipalPermissionsGrants(principalResourcePermissions, catalogId)); err != nil {
+		return fmt.Errorf("error setting grant: %w", err)
+	}
+
+	return nil
+}
+
+// flattenLakeFormationPrincipalPermissionsGrants returns the grants in the shape of the aws_lakeformation_permissions
+// resource, sorted by a unique key that can be used with for_each. The table with columns entry AWS creates for a
+// SELECT grant on a table is folded into the table grant, as the resource creates both from a single table block.
+func flattenLakeFormationPrincipalPermissionsGrants(apiObjects []*lakeformation.PrincipalResourcePermissions, catalogId string) []interface{} {
+	var normalized []*lakeformation.PrincipalResourcePermissions
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil {
+			continue
+		}
+
+		v := *apiObject
+		apiResource := tflakeformation.ResourceWithEffectiveCatalogID(*apiObject.Resource, catalogId)
+
+		if twc := apiResource.TableWithColumns; twc != nil && len(twc.ColumnNames) == 0 && twc.ColumnWildcard != nil && len(twc.ColumnWildcard.ExcludedColumnNames) == 0 {
+			apiResource = lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    twc.CatalogId,
+					DatabaseName: twc.DatabaseName,
+					Name:         twc.Name,
+				},
+			}
+		}
+
+		v.AdditionalDetails = nil
+		v.Resource = &apiResource
+		normalized = append(normalized, &v)
+	}
+
+	var tfList []interface{}
+
+	for _, apiObject := range tflakeformation.PermissionsAggregate(normalized) {
+		tfMap := map[string]interface{}{
+			"catalog_resource":              apiObject.Resource.Catalog != nil,
+			"key":                           tflakeformation.PermissionsResourceKey(catalogId, apiObject.Resource),
+			"permissions":                   flattenStringSet(apiObject.Permissions),
+			"permissions_with_grant_option": flattenStringSet(apiObject.PermissionsWithGrantOption),
+		}
+
+		if v := apiObject.Resource.DataLocation; v != nil {
+			tfMap["data_location"] = []interface{}{flattenLakeFormationDataLocationResource(v)}
+		}
+
+		if v := apiObject.Resource.Database; v != nil {
+			tfMap["database"] = []interface{}{flattenLakeFormationDatabaseResource(v)}
+		}
+
+		if v := apiObject.Resource.Table; v != nil {
+			tfMap["table"] = []interface{}{flattenLakeFormationTableResource(v)}
+		}
+
+		if v := apiObject.Resource.TableWithColumns; v != nil {
+			tfMap["table_with_columns"] = []interface{}{flattenLakeFormationTableWithColumnsResource(v)}
+		}
+
+		tfList = append(tfList, tfMap)
+	}
+
+	sort.Slice(tfList, func(i, j int) bool {
+		return tfList[i].(map[string]interface{})["key"].(string) < tfList[j].(map[string]interface{})["key"].(string)
+	})
+
+	return tfList
+}
@@ -0,0 +1,220 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFlattenLakeFormationPrincipalPermissionsGrants(t *testing.T) {
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+
+	apiObjects := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("tbl"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnNames:  aws.StringSlice([]string{"event", "timestamp"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("other"),
+				},
+			},
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+		},
+	}
+
+	got := flattenLakeFormationPrincipalPermissionsGrants(apiObjects, "123456789012")
+
+	expectedKeys := []string{
+		"DATABASE,123456789012,db",
+		"TABLE,123456789012,db,tbl",
+		"TABLE_WITH_COLUMNS,123456789012,db,other,event+timestamp",
+	}
+
+	if len(got) != len(expectedKeys) {
+		t.Fatalf("expected %d grants, got %d: %v", len(expectedKeys), len(got), got)
+	}
+
+	for i, expected := range expectedKeys {
+		if key := got[i].(map[string]interface{})["key"]; key != expected {
+			t.Errorf("grant %d: expected key %q, got %q", i, expected, key)
+		}
+	}
+
+	table := got[1].(map[string]interface{})
+
+	if expected, permissions := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionAlter, lakeformation.PermissionSelect}), table["permissions"].(*schema.Set); !expected.Equal(permissions) {
+		t.Errorf("expected the SELECT companion to be folded into the table grant, got %v", permissions.List())
+	}
+
+	if _, ok := table["table_with_columns"]; ok {
+		t.Errorf("expected no table_with_columns block for the table grant, got %v", table)
+	}
+
+	// Each grant must round-trip through the aws_lakeformation_permissions resource expanders.
+	tableBlock := table["table"].([]interface{})[0].(map[string]interface{})
+	if expected, got := (&lakeformation.TableResource{CatalogId: aws.String("123456789012"), DatabaseName: aws.String("db"), Name: aws.String("tbl")}), expandLakeFormationTableResource(tableBlock); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected table %v, got %v", expected, got)
+	}
+
+	columnsBlock := got[2].(map[string]interface{})["table_with_columns"].([]interface{})[0].(map[string]interface{})
+	expectedColumns := &lakeformation.TableWithColumnsResource{
+		CatalogId:    aws.String("123456789012"),
+		ColumnNames:  aws.StringSlice([]string{"event", "timestamp"}),
+		DatabaseName: aws.String("db"),
+		Name:         aws.String("other"),
+	}
+	if got := expandLakeFormationTableWithColumnsResource(columnsBlock); !reflect.DeepEqual(expectedColumns, got) {
+		t.Errorf("expected table with columns %v, got %v", expectedColumns, got)
+	}
+
+	database := got[0].(map[string]interface{})
+	if expected, got := (&lakeformation.DatabaseResource{CatalogId: aws.String("123456789012"), Name: aws.String("db")}), expandLakeFormationDatabaseResource(database["database"].([]interface{})[0].(map[string]interface{})); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected database %v, got %v", expected, got)
+	}
+
+	if grantOptions := database["permissions_with_grant_option"].(*schema.Set); grantOptions.Len() != 1 || !grantOptions.Contains(lakeformation.PermissionCreateTable) {
+		t.Errorf("expected CREATE_TABLE grant option, got %v", grantOptions.List())
+	}
+}
+
+func testAccAWSLakeFormationPrincipalPermissionsDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_lakeformation_principal_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPrincipalPermissionsDataSourceConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "principal", "aws_iam_role.test", "arn"),
+					resource.TestCheckResourceAttr(dataSourceName, "grant.#", "2"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "grant.0.database.0.name", "aws_glue_catalog_database.test", "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "grant.0.permissions.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "grant.1.table.0.name", "aws_glue_catalog_table.test", "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "grant.1.permissions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "grant.1.table_with_columns.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationPrincipalPermissionsDataSourceConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+}
+
+resource "aws_lakeformation_permissions" "database" {
+  permissions = ["CREATE_TABLE"]
+  principal   = aws_iam_role.test.arn
+
+  database {
+    name = aws_glue_catalog_database.test.name
+  }
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+
+resource "aws_lakeformation_permissions" "table" {
+  permissions = ["ALTER", "SELECT"]
+  principal   = aws_iam_role.test.arn
+
+  table {
+    database_name = aws_glue_catalog_table.test.database_name
+    name          = aws_glue_catalog_table.test.name
+  }
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+
+data "aws_lakeformation_principal_permissions" "test" {
+  principal = aws_iam_role.test.arn
+
+  depends_on = [
+    aws_lakeformation_permissions.database,
+    aws_lakeformation_permissions.table,
+  ]
+}
+`, rName)
+}
@@ -0,0 +1,24 @@
+package finder
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+)
+
+// ResourceByARN returns the registered resource corresponding to the specified ARN.
+func ResourceByARN(conn *lakeformation.LakeFormation, arn string) (*lakeformation.ResourceInfo, error) {
+	input := &lakeformation.DescribeResourceInput{
+		ResourceArn: aws.String(arn),
+	}
+
+	output, err := conn.DescribeResource(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return output.ResourceInfo, nil
+}
@@ -0,0 +1,352 @@
+package lakeformation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+)
+
+const databaseDefaultPermissionsIDSeparator = ","
+
+func DatabaseDefaultPermissionsCreateID(catalogID, principal string) string {
+	return catalogID + databaseDefaultPermissionsIDSeparator + principal
+}
+
+func DatabaseDefaultPermissionsParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, databaseDefaultPermissionsIDSeparator, 2)
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "", fmt.Errorf("unexpected format for ID (%q), expected <catalog-id>"+databaseDefaultPermissionsIDSeparator+"<principal>", id)
+}
+
+const (
+	// DataLakeResourceTypeTableWithColumns has no lakeformation package enum value.
+	DataLakeResourceTypeTableWithColumns = "TABLE_WITH_COLUMNS"
+
+	// PermissionsResourceTypeDatabases identifies a grant on several databases in a permissions ID.
+	PermissionsResourceTypeDatabases = "DATABASES"
+
+	// TableNameAllTables is the table name Lake Formation reports for a grant on all tables in a database.
+	TableNameAllTables = "ALL_TABLES"
+)
+
+// Terraform state IDs for permissions are made of comma-separated fields, e.g.
+// arn:aws:iam::123456789012:role/example,TABLE,123456789012,db,tbl. Commas and percent signs within a field are
+// percent-encoded, so that principals, location ARNs and names can hold any character.
+
+const (
+	permissionsIDSeparator             = ","
+	permissionsIDWildcard              = "*"
+	permissionsIDColumnSeparator       = "+"
+	permissionsIDExcludedColumnsPrefix = "-"
+	permissionsIDFormat                = "<principal>,<resource-type>,<catalog-id>[,<identifier>...]"
+)
+
+var accountIDRegexp = regexp.MustCompile(`^\d{12}$`)
+
+var permissionsIDEscaper = strings.NewReplacer("%", "%25", ",", "%2C")
+
+var permissionsIDColumnEscaper = strings.NewReplacer("%", "%25", ",", "%2C", "+", "%2B")
+
+// ResourceType returns the Lake Formation resource type of an API resource.
+func ResourceType(apiObject *lakeformation.Resource) string {
+	if apiObject == nil {
+		return ""
+	}
+
+	switch {
+	case apiObject.Catalog != nil:
+		return lakeformation.DataLakeResourceTypeCatalog
+	case apiObject.DataLocation != nil:
+		return lakeformation.DataLakeResourceTypeDataLocation
+	case apiObject.Database != nil:
+		return lakeformation.DataLakeResourceTypeDatabase
+	case apiObject.Table != nil:
+		return lakeformation.DataLakeResourceTypeTable
+	case apiObject.TableWithColumns != nil:
+		return DataLakeResourceTypeTableWithColumns
+	}
+
+	return ""
+}
+
+// PermissionsCreateID returns the ID of the grant to principal on a resource. catalogID is used for the catalog
+// resource and for resources without a catalog ID.
+func PermissionsCreateID(principal, catalogID string, apiObject *lakeformation.Resource) string {
+	return permissionsIDEscaper.Replace(principal) + permissionsIDSeparator + PermissionsResourceKey(catalogID, apiObject)
+}
+
+// PermissionsDatabasesCreateID returns the ID of the grant to principal on several databases, e.g.
+// 123456789012,DATABASES,123456789012,sales,123456789012,marketing.
+func PermissionsDatabasesCreateID(principal, catalogID string, apiObjects []*lakeformation.DatabaseResource) string {
+	keys := make([]string, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		keys = append(keys, permissionsIDJoin(permissionsIDCatalogID(apiObject.CatalogId, catalogID), aws.StringValue(apiObject.Name)))
+	}
+
+	sort.Strings(keys)
+
+	return permissionsIDJoin(principal, PermissionsResourceTypeDatabases) + permissionsIDSeparator + strings.Join(keys, permissionsIDSeparator)
+}
+
+// PermissionsResourceKey returns the fields of a permissions ID that identify the resource:
+// <resource-type>,<catalog-id>[,<identifier>...]. The identifier depends on the resource type:
+//
+//   - CATALOG: none
+//   - DATA_LOCATION: the location ARN
+//   - DATABASE: the database name
+//   - TABLE: the database and table names, or * for all tables in the database
+//   - TABLE_WITH_COLUMNS: the database and table names, then * for all columns, the column names joined with +, or
+//     the excluded column names joined with + and prefixed with -
+func PermissionsResourceKey(catalogID string, apiObject *lakeformation.Resource) string {
+	switch {
+	case apiObject.Catalog != nil:
+		return permissionsIDJoin(lakeformation.DataLakeResourceTypeCatalog, catalogID)
+	case apiObject.DataLocation != nil:
+		v := apiObject.DataLocation
+		return permissionsIDJoin(lakeformation.DataLakeResourceTypeDataLocation, permissionsIDCatalogID(v.CatalogId, catalogID), aws.StringValue(v.ResourceArn))
+	case apiObject.Database != nil:
+		v := apiObject.Database
+		return permissionsIDJoin(lakeformation.DataLakeResourceTypeDatabase, permissionsIDCatalogID(v.CatalogId, catalogID), aws.StringValue(v.Name))
+	case apiObject.Table != nil:
+		v := apiObject.Table
+		name := aws.StringValue(v.Name)
+		if v.TableWildcard != nil || name == TableNameAllTables {
+			name = permissionsIDWildcard
+		}
+		return permissionsIDJoin(lakeformation.DataLakeResourceTypeTable, permissionsIDCatalogID(v.CatalogId, catalogID), aws.StringValue(v.DatabaseName), name)
+	case apiObject.TableWithColumns != nil:
+		v := apiObject.TableWithColumns
+		key := permissionsIDJoin(DataLakeResourceTypeTableWithColumns, permissionsIDCatalogID(v.CatalogId, catalogID), aws.StringValue(v.DatabaseName), aws.StringValue(v.Name))
+
+		switch {
+		case len(v.ColumnNames) > 0:
+			return key + permissionsIDSeparator + permissionsIDColumns(v.ColumnNames)
+		case v.ColumnWildcard != nil && len(v.ColumnWildcard.ExcludedColumnNames) > 0:
+			return key + permissionsIDSeparator + permissionsIDExcludedColumnsPrefix + permissionsIDColumns(v.ColumnWildcard.ExcludedColumnNames)
+		default:
+			return key + permissionsIDSeparator + permissionsIDWildcard
+		}
+	}
+
+	return ""
+}
+
+// PermissionsParseID parses a permissions ID into the principal, the resource type, the catalog ID and the
+// resources, of which there is more than one only for DATABASES IDs. The catalog ID may be empty to use the account ID.
+func PermissionsParseID(id string) (string, string, string, []*lakeformation.Resource, error) {
+	parts := strings.Split(id, permissionsIDSeparator)
+
+	if len(parts) < 3 {
+		return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q), expected %s", id, permissionsIDFormat)
+	}
+
+	for i, part := range parts {
+		// Column lists are unescaped once split.
+		if i == 5 && parts[1] == DataLakeResourceTypeTableWithColumns {
+			continue
+		}
+
+		v, err := url.PathUnescape(part)
+
+		if err != nil {
+			return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q): %w", id, err)
+		}
+
+		parts[i] = v
+	}
+
+	principal, resourceType, catalogID, identifier := parts[0], parts[1], parts[2], parts[3:]
+
+	if principal == "" {
+		return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q), expected %s", id, permissionsIDFormat)
+	}
+
+	// The catalog ID is always the numeric account ID, never an account alias.
+	if !permissionsIDValidCatalogID(catalogID) {
+		return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q): invalid catalog ID (%s)", id, catalogID)
+	}
+
+	var catalogIDPtr *string
+	if catalogID != "" {
+		catalogIDPtr = aws.String(catalogID)
+	}
+
+	switch resourceType {
+	case lakeformation.DataLakeResourceTypeCatalog:
+		if len(identifier) == 0 {
+			return principal, resourceType, catalogID, []*lakeformation.Resource{{Catalog: &lakeformation.CatalogResource{}}}, nil
+		}
+	case lakeformation.DataLakeResourceTypeDataLocation:
+		if len(identifier) == 1 && identifier[0] != "" {
+			return principal, resourceType, catalogID, []*lakeformation.Resource{{
+				DataLocation: &lakeformation.DataLocationResource{
+					CatalogId:   catalogIDPtr,
+					ResourceArn: aws.String(identifier[0]),
+				},
+			}}, nil
+		}
+	case lakeformation.DataLakeResourceTypeDatabase:
+		if len(identifier) == 1 && identifier[0] != "" {
+			return principal, resourceType, catalogID, []*lakeformation.Resource{{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: catalogIDPtr,
+					Name:      aws.String(identifier[0]),
+				},
+			}}, nil
+		}
+	case PermissionsResourceTypeDatabases:
+		// The catalog ID and name of each database follow the resource type.
+		if apiObjects := permissionsIDParseDatabases(parts[2:]); apiObjects != nil {
+			return principal, resourceType, catalogID, apiObjects, nil
+		}
+	case lakeformation.DataLakeResourceTypeTable:
+		if len(identifier) == 2 && identifier[0] != "" && identifier[1] != "" {
+			apiObject := &lakeformation.TableResource{
+				CatalogId:    catalogIDPtr,
+				DatabaseName: aws.String(identifier[0]),
+			}
+
+			if identifier[1] == permissionsIDWildcard {
+				apiObject.TableWildcard = &lakeformation.TableWildcard{}
+			} else {
+				apiObject.Name = aws.String(identifier[1])
+			}
+
+			return principal, resourceType, catalogID, []*lakeformation.Resource{{Table: apiObject}}, nil
+		}
+	case DataLakeResourceTypeTableWithColumns:
+		if len(identifier) == 3 && identifier[0] != "" && identifier[1] != "" && strings.TrimPrefix(identifier[2], permissionsIDExcludedColumnsPrefix) != "" {
+			apiObject := &lakeformation.TableWithColumnsResource{
+				CatalogId:    catalogIDPtr,
+				DatabaseName: aws.String(identifier[0]),
+				Name:         aws.String(identifier[1]),
+			}
+
+			if identifier[2] == permissionsIDWildcard {
+				apiObject.ColumnWildcard = &lakeformation.ColumnWildcard{}
+				return principal, resourceType, catalogID, []*lakeformation.Resource{{TableWithColumns: apiObject}}, nil
+			}
+
+			excluded := strings.HasPrefix(identifier[2], permissionsIDExcludedColumnsPrefix)
+			columnNames, err := permissionsIDParseColumns(strings.TrimPrefix(identifier[2], permissionsIDExcludedColumnsPrefix))
+
+			if err != nil {
+				return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q): %w", id, err)
+			}
+
+			if excluded {
+				apiObject.ColumnWildcard = &lakeformation.ColumnWildcard{
+					ExcludedColumnNames: columnNames,
+				}
+			} else {
+				apiObject.ColumnNames = columnNames
+			}
+
+			return principal, resourceType, catalogID, []*lakeformation.Resource{{TableWithColumns: apiObject}}, nil
+		}
+	}
+
+	return "", "", "", nil, fmt.Errorf("unexpected format for ID (%q) of resource type %q, expected %s", id, resourceType, permissionsIDFormat)
+}
+
+// permissionsIDParseDatabases returns the databases of a list of catalog ID and name pairs, or nil if the list is
+// not made of pairs with a name.
+func permissionsIDParseDatabases(fields []string) []*lakeformation.Resource {
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return nil
+	}
+
+	apiObjects := make([]*lakeformation.Resource, 0, len(fields)/2)
+
+	for i := 0; i < len(fields); i += 2 {
+		if fields[i+1] == "" || !permissionsIDValidCatalogID(fields[i]) {
+			return nil
+		}
+
+		apiObject := &lakeformation.DatabaseResource{
+			Name: aws.String(fields[i+1]),
+		}
+
+		if fields[i] != "" {
+			apiObject.CatalogId = aws.String(fields[i])
+		}
+
+		apiObjects = append(apiObjects, &lakeformation.Resource{Database: apiObject})
+	}
+
+	return apiObjects
+}
+
+func permissionsIDValidCatalogID(catalogID string) bool {
+	return catalogID == "" || accountIDRegexp.MatchString(catalogID)
+}
+
+func permissionsIDJoin(fields ...string) string {
+	escaped := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		escaped = append(escaped, permissionsIDEscaper.Replace(field))
+	}
+
+	return strings.Join(escaped, permissionsIDSeparator)
+}
+
+func permissionsIDCatalogID(catalogID *string, defaultCatalogID string) string {
+	if v := aws.StringValue(catalogID); v != "" {
+		return v
+	}
+
+	return defaultCatalogID
+}
+
+// permissionsIDColumns returns the sorted column names joined with +. A leading - or a lone * is escaped so that
+// the list is not read as excluded columns or all columns.
+func permissionsIDColumns(columnNames []*string) string {
+	v := aws.StringValueSlice(columnNames)
+	sort.Strings(v)
+
+	for i, name := range v {
+		v[i] = permissionsIDColumnEscaper.Replace(name)
+	}
+
+	columns := strings.Join(v, permissionsIDColumnSeparator)
+
+	if strings.HasPrefix(columns, permissionsIDExcludedColumnsPrefix) {
+		columns = "%2D" + strings.TrimPrefix(columns, permissionsIDExcludedColumnsPrefix)
+	}
+
+	if columns == permissionsIDWildcard {
+		columns = "%2A"
+	}
+
+	return columns
+}
+
+func permissionsIDParseColumns(columns string) ([]*string, error) {
+	var columnNames []*string
+
+	for _, name := range strings.Split(columns, permissionsIDColumnSeparator) {
+		v, err := url.PathUnescape(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if v == "" {
+			return nil, fmt.Errorf("empty column name")
+		}
+
+		columnNames = append(columnNames, aws.String(v))
+	}
+
+	return columnNames, nil
+}
@@ -0,0 +1,276 @@
+package lakeformation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func TestPermissionsCreateID(t *testing.T) {
+	principal := "arn:aws:iam::123456789012:role/test" //lintignore:AWSAT003,AWSAT005
+
+	testCases := []struct {
+		TestName   string
+		Principal  string
+		Resource   *lakeformation.Resource
+		ExpectedID string
+	}{
+		{
+			TestName:   "catalog",
+			Principal:  principal,
+			Resource:   &lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}},
+			ExpectedID: principal + ",CATALOG,123456789012",
+		},
+		{
+			TestName:  "data location",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				DataLocation: &lakeformation.DataLocationResource{ResourceArn: aws.String("arn:aws:s3:::bucket/x,y")}, //lintignore:AWSAT005
+			},
+			ExpectedID: principal + ",DATA_LOCATION,123456789012,arn:aws:s3:::bucket/x%2Cy", //lintignore:AWSAT005
+		},
+		{
+			TestName:  "database in another catalog",
+			Principal: "arn:aws:iam::123456789012:role/a,b", //lintignore:AWSAT003,AWSAT005
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{CatalogId: aws.String("111122223333"), Name: aws.String("db")},
+			},
+			ExpectedID: "arn:aws:iam::123456789012:role/a%2Cb,DATABASE,111122223333,db", //lintignore:AWSAT003,AWSAT005
+		},
+		{
+			TestName:  "table with dot in name",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{DatabaseName: aws.String("db"), Name: aws.String("a.b")},
+			},
+			ExpectedID: principal + ",TABLE,123456789012,db,a.b",
+		},
+		{
+			TestName:  "table wildcard",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{DatabaseName: aws.String("db"), TableWildcard: &lakeformation.TableWildcard{}},
+			},
+			ExpectedID: principal + ",TABLE,123456789012,db,*",
+		},
+		{
+			TestName:  "table with column names",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnNames:  aws.StringSlice([]string{"timestamp", "event"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			ExpectedID: principal + ",TABLE_WITH_COLUMNS,123456789012,db,tbl,event+timestamp",
+		},
+		{
+			TestName:  "table with excluded column names",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnWildcard: &lakeformation.ColumnWildcard{
+						ExcludedColumnNames: aws.StringSlice([]string{"a+b", "value"}),
+					},
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			ExpectedID: principal + ",TABLE_WITH_COLUMNS,123456789012,db,tbl,-a%2Bb+value",
+		},
+		{
+			TestName:  "table with column name starting with a dash",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnNames:  aws.StringSlice([]string{"-value"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			ExpectedID: principal + ",TABLE_WITH_COLUMNS,123456789012,db,tbl,%2Dvalue",
+		},
+		{
+			TestName:  "table with all columns",
+			Principal: principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("tbl"),
+				},
+			},
+			ExpectedID: principal + ",TABLE_WITH_COLUMNS,123456789012,db,tbl,*",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			id := tflakeformation.PermissionsCreateID(testCase.Principal, "123456789012", testCase.Resource)
+
+			if id != testCase.ExpectedID {
+				t.Fatalf("expected ID %q, got %q", testCase.ExpectedID, id)
+			}
+
+			principal, _, catalogID, resources, err := tflakeformation.PermissionsParseID(id)
+
+			if err != nil {
+				t.Fatalf("unexpected error parsing ID %q: %s", id, err)
+			}
+
+			if principal != testCase.Principal {
+				t.Errorf("expected principal %q, got %q", testCase.Principal, principal)
+			}
+
+			if len(resources) != 1 {
+				t.Fatalf("expected 1 resource, got %v", resources)
+			}
+
+			if got := tflakeformation.PermissionsCreateID(principal, catalogID, resources[0]); got != id {
+				t.Errorf("expected parsed ID to round-trip to %q, got %q", id, got)
+			}
+		})
+	}
+}
+
+func TestPermissionsDatabasesCreateID(t *testing.T) {
+	databases := []*lakeformation.DatabaseResource{
+		{Name: aws.String("sales")},
+		{CatalogId: aws.String("111122223333"), Name: aws.String("marketing")},
+	}
+
+	id := tflakeformation.PermissionsDatabasesCreateID("123456789012", "123456789012", databases)
+
+	if expected := "123456789012,DATABASES,111122223333,marketing,123456789012,sales"; id != expected {
+		t.Fatalf("expected ID %q, got %q", expected, id)
+	}
+
+	principal, resourceType, _, resources, err := tflakeformation.PermissionsParseID(id)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if principal != "123456789012" || resourceType != tflakeformation.PermissionsResourceTypeDatabases {
+		t.Errorf("expected principal 123456789012 and resource type DATABASES, got %q and %q", principal, resourceType)
+	}
+
+	expected := []*lakeformation.Resource{
+		{Database: &lakeformation.DatabaseResource{CatalogId: aws.String("111122223333"), Name: aws.String("marketing")}},
+		{Database: &lakeformation.DatabaseResource{CatalogId: aws.String("123456789012"), Name: aws.String("sales")}},
+	}
+
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected %v, got %v", expected, resources)
+	}
+}
+
+func TestPermissionsParseID(t *testing.T) {
+	testCases := []struct {
+		TestName          string
+		InputID           string
+		ExpectedPrincipal string
+		ExpectedCatalogID string
+		ExpectedResource  *lakeformation.Resource
+		ExpectedError     bool
+	}{
+		{
+			TestName:          "catalog",
+			InputID:           "arn:aws:iam::123456789012:role/test,CATALOG,123456789012", //lintignore:AWSAT003,AWSAT005
+			ExpectedPrincipal: "arn:aws:iam::123456789012:role/test",                      //lintignore:AWSAT003,AWSAT005
+			ExpectedCatalogID: "123456789012",
+			ExpectedResource:  &lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}},
+		},
+		{
+			TestName:          "external IdP group without catalog ID",
+			InputID:           "arn:aws:iam::123456789012:saml-provider/idp1:group/data-scientists,DATABASE,,db", //lintignore:AWSAT003,AWSAT005
+			ExpectedPrincipal: "arn:aws:iam::123456789012:saml-provider/idp1:group/data-scientists",              //lintignore:AWSAT003,AWSAT005
+			ExpectedResource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+		},
+		{
+			TestName:          "table in another catalog",
+			InputID:           "123456789012,TABLE,111122223333,db,tbl",
+			ExpectedPrincipal: "123456789012",
+			ExpectedCatalogID: "111122223333",
+			ExpectedResource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("111122223333"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		},
+		{
+			TestName:      "unescaped comma",
+			InputID:       "arn:aws:iam::123456789012:role/a,b,DATABASE,,db", //lintignore:AWSAT003,AWSAT005
+			ExpectedError: true,
+		},
+		{
+			TestName:      "invalid escape",
+			InputID:       "123456789012,DATABASE,,db%zz",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "table with columns without columns",
+			InputID:       "123456789012,TABLE_WITH_COLUMNS,,db,tbl",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "no resource type",
+			InputID:       "123456789012,db,tbl",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "catalog ID account alias",
+			InputID:       "123456789012,DATABASE,example-alias,db",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "no catalog ID",
+			InputID:       "123456789012,CATALOG",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "databases without name",
+			InputID:       "123456789012,DATABASES,123456789012",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			principal, _, catalogID, resources, err := tflakeformation.PermissionsParseID(testCase.InputID)
+
+			if testCase.ExpectedError {
+				if err == nil {
+					t.Fatalf("expected error, got %v", resources)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if principal != testCase.ExpectedPrincipal {
+				t.Errorf("expected principal %q, got %q", testCase.ExpectedPrincipal, principal)
+			}
+
+			if catalogID != testCase.ExpectedCatalogID {
+				t.Errorf("expected catalog ID %q, got %q", testCase.ExpectedCatalogID, catalogID)
+			}
+
+			if len(resources) != 1 || !reflect.DeepEqual(resources[0], testCase.ExpectedResource) {
+				t.Errorf("expected resource %v, got %v", testCase.ExpectedResource, resources)
+			}
+		})
+	}
+}
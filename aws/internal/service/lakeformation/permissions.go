@@ -0,0 +1,151 @@
+package lakeformation
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+)
+
+// PermissionsIsResourceShared reports whether the permissions were granted across accounts through AWS RAM.
+func PermissionsIsResourceShared(apiObject *lakeformation.PrincipalResourcePermissions) bool {
+	return apiObject != nil && apiObject.AdditionalDetails != nil && len(apiObject.AdditionalDetails.ResourceShare) > 0
+}
+
+// PermissionsAggregate merges entries describing the same principal and resource.
+// Cross-account grants made through AWS RAM can be reported once per resource share, with the entries
+// differing only in their AdditionalDetails.
+func PermissionsAggregate(apiObjects []*lakeformation.PrincipalResourcePermissions) []*lakeformation.PrincipalResourcePermissions {
+	var aggregated []*lakeformation.PrincipalResourcePermissions
+	seen := make(map[string]*lakeformation.PrincipalResourcePermissions)
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil {
+			continue
+		}
+
+		var principal string
+		if apiObject.Principal != nil {
+			principal = aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier)
+		}
+
+		key := principal + "|" + apiObject.Resource.String()
+
+		existing, ok := seen[key]
+		if !ok {
+			v := *apiObject
+			if v.AdditionalDetails != nil {
+				v.AdditionalDetails = &lakeformation.DetailsMap{
+					ResourceShare: appendUniqueStrings(nil, v.AdditionalDetails.ResourceShare),
+				}
+			}
+			v.Permissions = appendUniqueStrings(nil, v.Permissions)
+			v.PermissionsWithGrantOption = appendUniqueStrings(nil, v.PermissionsWithGrantOption)
+
+			seen[key] = &v
+			aggregated = append(aggregated, &v)
+			continue
+		}
+
+		existing.Permissions = appendUniqueStrings(existing.Permissions, apiObject.Permissions)
+		existing.PermissionsWithGrantOption = appendUniqueStrings(existing.PermissionsWithGrantOption, apiObject.PermissionsWithGrantOption)
+
+		if apiObject.AdditionalDetails != nil {
+			if existing.AdditionalDetails == nil {
+				existing.AdditionalDetails = &lakeformation.DetailsMap{}
+			}
+			existing.AdditionalDetails.ResourceShare = appendUniqueStrings(existing.AdditionalDetails.ResourceShare, apiObject.AdditionalDetails.ResourceShare)
+		}
+	}
+
+	return aggregated
+}
+
+// appendUniqueStrings appends the values in src that are not already present in dst.
+func appendUniqueStrings(dst, src []*string) []*string {
+	for _, v := range src {
+		if v == nil {
+			continue
+		}
+
+		found := false
+		for _, existing := range dst {
+			if aws.StringValue(existing) == aws.StringValue(v) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			dst = append(dst, v)
+		}
+	}
+
+	return dst
+}
+
+// ListPermissionsResourceType returns the resource type used to filter ListPermissions server-side.
+// Table with columns entries, including the column wildcard entry that accompanies a SELECT grant on a table,
+// are listed under the table resource type.
+func ListPermissionsResourceType(resourceType string) string {
+	if resourceType == DataLakeResourceTypeTableWithColumns {
+		return lakeformation.DataLakeResourceTypeTable
+	}
+
+	return resourceType
+}
+
+// allPermissionExpansions are the permissions that ALL is expanded to for each resource type.
+var allPermissionExpansions = map[string][]string{
+	lakeformation.DataLakeResourceTypeDatabase: {
+		lakeformation.PermissionAlter,
+		lakeformation.PermissionCreateTable,
+		lakeformation.PermissionDescribe,
+		lakeformation.PermissionDrop,
+	},
+	lakeformation.DataLakeResourceTypeTable: {
+		lakeformation.PermissionAlter,
+		lakeformation.PermissionDelete,
+		lakeformation.PermissionDescribe,
+		lakeformation.PermissionDrop,
+		lakeformation.PermissionInsert,
+		lakeformation.PermissionSelect,
+	},
+}
+
+// PermissionsWithExpandedAll adds ALL to permissions when they hold its full expansion for the resource
+// type while counterpart holds ALL itself. AWS can report ALL granted with grant option collapsed in one of the
+// permissions and grant option lists but expanded in the other, so both are then collapsed the same way.
+func PermissionsWithExpandedAll(resourceType string, permissions, counterpart []string) []string {
+	expansion, ok := allPermissionExpansions[resourceType]
+
+	if !ok {
+		return permissions
+	}
+
+	reported := make(map[string]bool, len(permissions))
+	for _, v := range permissions {
+		reported[v] = true
+	}
+
+	if reported[lakeformation.PermissionAll] {
+		return permissions
+	}
+
+	var counterpartAll bool
+	for _, v := range counterpart {
+		if v == lakeformation.PermissionAll {
+			counterpartAll = true
+		}
+	}
+
+	if !counterpartAll {
+		return permissions
+	}
+
+	for _, v := range expansion {
+		if !reported[v] {
+			return permissions
+		}
+	}
+
+	return append([]string{lakeformation.PermissionAll}, permissions...)
+}
@@ -0,0 +1,103 @@
+package lakeformation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func TestPermissionsAggregate(t *testing.T) {
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("123456789012"),
+	}
+	res := &lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			CatalogId: aws.String("111122223333"),
+			Name:      aws.String("shared"),
+		},
+	}
+
+	input := []*lakeformation.PrincipalResourcePermissions{
+		{
+			AdditionalDetails: &lakeformation.DetailsMap{
+				ResourceShare: aws.StringSlice([]string{"arn:aws:ram:us-east-1:111122223333:resource-share/one"}), //lintignore:AWSAT003,AWSAT005
+			},
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:                  principal,
+			Resource:                   res,
+		},
+		{
+			AdditionalDetails: &lakeformation.DetailsMap{
+				ResourceShare: aws.StringSlice([]string{"arn:aws:ram:us-east-1:111122223333:resource-share/two"}), //lintignore:AWSAT003,AWSAT005
+			},
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionDescribe, lakeformation.PermissionAlter}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:                  principal,
+			Resource:                   res,
+		},
+	}
+
+	got := tflakeformation.PermissionsAggregate(input)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 aggregated entry, got %d", len(got))
+	}
+
+	if expected, actual := []string{lakeformation.PermissionDescribe, lakeformation.PermissionAlter}, aws.StringValueSlice(got[0].Permissions); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected permissions %v, got %v", expected, actual)
+	}
+
+	if expected, actual := []string{lakeformation.PermissionDescribe}, aws.StringValueSlice(got[0].PermissionsWithGrantOption); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected permissions with grant option %v, got %v", expected, actual)
+	}
+
+	if expected, actual := 2, len(got[0].AdditionalDetails.ResourceShare); expected != actual {
+		t.Errorf("expected %d resource shares, got %d", expected, actual)
+	}
+
+	if expected, actual := 1, len(input[0].Permissions); expected != actual {
+		t.Errorf("input entry was modified: expected %d permissions, got %d", expected, actual)
+	}
+}
+
+func TestListPermissionsResourceType(t *testing.T) {
+	testCases := []struct {
+		ResourceType string
+		Expected     string
+	}{
+		{
+			ResourceType: lakeformation.DataLakeResourceTypeCatalog,
+			Expected:     lakeformation.DataLakeResourceTypeCatalog,
+		},
+		{
+			ResourceType: lakeformation.DataLakeResourceTypeDataLocation,
+			Expected:     lakeformation.DataLakeResourceTypeDataLocation,
+		},
+		{
+			ResourceType: lakeformation.DataLakeResourceTypeDatabase,
+			Expected:     lakeformation.DataLakeResourceTypeDatabase,
+		},
+		{
+			// The SELECT companion of a table grant is listed under the table resource type.
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			Expected:     lakeformation.DataLakeResourceTypeTable,
+		},
+		{
+			// The squashed table lookup for table with columns returns both entry types.
+			ResourceType: tflakeformation.DataLakeResourceTypeTableWithColumns,
+			Expected:     lakeformation.DataLakeResourceTypeTable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.ResourceType, func(t *testing.T) {
+			if got := tflakeformation.ListPermissionsResourceType(testCase.ResourceType); got != testCase.Expected {
+				t.Errorf("expected %s, got %s", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package lakeformation
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+)
+
+// ResourceEqual reports whether out, as listed by ListPermissions, describes the resource in. A resource without a
+// catalog ID takes the one of out.
+func ResourceEqual(in, out lakeformation.Resource) bool {
+	// Database and table resources can share a database name, so never let one stand in for the other.
+	if ResourceType(&in) != ResourceType(&out) {
+		return false
+	}
+
+	// A resource block without a catalog ID takes the one AWS reports. A copy is compared so that the caller's
+	// resource keeps matching entries listed afterwards.
+	if ResourceCatalogID(&in) == nil {
+		in = ResourceWithCatalogID(in, ResourceCatalogID(&out))
+	}
+
+	// Only an allow-list of identity fields is compared for each resource type. Lake Formation adds details to the
+	// resource representations over time, which must not keep an otherwise identical grant from reconciling.
+	switch ResourceType(&in) {
+	case lakeformation.DataLakeResourceTypeCatalog:
+		// The Data Catalog has no identifying fields of its own, so grants made by different data lake admins
+		// all refer to the same catalog and are aggregated.
+		return true
+	case lakeformation.DataLakeResourceTypeDataLocation:
+		// Grants on a parent and a child S3 prefix are listed for the same principal, so each configuration
+		// must only match its own location.
+		return dataLocationResourceEqual(in.DataLocation, out.DataLocation)
+	case lakeformation.DataLakeResourceTypeDatabase:
+		return databaseResourceEqual(in.Database, out.Database)
+	case lakeformation.DataLakeResourceTypeTable:
+		// Tables in open table formats, such as Apache Iceberg, reconcile regardless of any format-specific
+		// details returned alongside them.
+		return tableResourceEqual(in.Table, out.Table)
+	case DataLakeResourceTypeTableWithColumns:
+		// Several grants on different column subsets of one table can exist for the same principal,
+		// so each configuration must only match its own column set.
+		return tableWithColumnsResourceEqual(in.TableWithColumns, out.TableWithColumns)
+	}
+
+	// A resource type unknown to this provider, e.g. one added to the API later, has no identity fields to compare.
+	return false
+}
+
+// SharedResourceEqual reports whether out matches in when out reports the grantor's (sharer's) catalog ID in place
+// of the resource owner's catalog ID that was configured. Any other catalog ID must still match exactly.
+func SharedResourceEqual(in, out lakeformation.Resource, grantorCatalogID string) bool {
+	if grantorCatalogID == "" {
+		return false
+	}
+
+	outCatalogID := ResourceCatalogID(&out)
+
+	if outCatalogID == nil || aws.StringValue(outCatalogID) != grantorCatalogID {
+		return false
+	}
+
+	inCatalogID := ResourceCatalogID(&in)
+
+	if inCatalogID == nil || aws.StringValue(inCatalogID) == grantorCatalogID {
+		// Nothing to reconcile; the regular comparison already applies.
+		return false
+	}
+
+	return ResourceEqual(ResourceWithCatalogID(in, outCatalogID), out)
+}
+
+// ResourceCatalogID returns the catalog ID of the data location, database, table, or table with columns.
+func ResourceCatalogID(apiObject *lakeformation.Resource) *string {
+	switch {
+	case apiObject.DataLocation != nil:
+		return apiObject.DataLocation.CatalogId
+	case apiObject.Database != nil:
+		return apiObject.Database.CatalogId
+	case apiObject.Table != nil:
+		return apiObject.Table.CatalogId
+	case apiObject.TableWithColumns != nil:
+		return apiObject.TableWithColumns.CatalogId
+	}
+
+	return nil
+}
+
+// ResourceWithEffectiveCatalogID returns a copy of the resource with a missing catalog ID set to catalogID.
+// Grants made while the account was still in IAM-only mode, before Lake Formation permissions were enforced, can be
+// listed with an empty catalog ID instead of none, so both are treated as missing.
+func ResourceWithEffectiveCatalogID(apiObject lakeformation.Resource, catalogID string) lakeformation.Resource {
+	if catalogID == "" {
+		return apiObject
+	}
+
+	switch ResourceType(&apiObject) {
+	case lakeformation.DataLakeResourceTypeDataLocation, lakeformation.DataLakeResourceTypeDatabase, lakeformation.DataLakeResourceTypeTable, DataLakeResourceTypeTableWithColumns:
+		if aws.StringValue(ResourceCatalogID(&apiObject)) == "" {
+			return ResourceWithCatalogID(apiObject, aws.String(catalogID))
+		}
+	}
+
+	return apiObject
+}
+
+// ResourceWithCatalogID returns a copy of the resource with its catalog ID replaced.
+func ResourceWithCatalogID(apiObject lakeformation.Resource, catalogID *string) lakeformation.Resource {
+	switch {
+	case apiObject.DataLocation != nil:
+		v := *apiObject.DataLocation
+		v.CatalogId = catalogID
+		apiObject.DataLocation = &v
+	case apiObject.Database != nil:
+		v := *apiObject.Database
+		v.CatalogId = catalogID
+		apiObject.Database = &v
+	case apiObject.Table != nil:
+		v := *apiObject.Table
+		v.CatalogId = catalogID
+		apiObject.Table = &v
+	case apiObject.TableWithColumns != nil:
+		v := *apiObject.TableWithColumns
+		v.CatalogId = catalogID
+		apiObject.TableWithColumns = &v
+	}
+
+	return apiObject
+}
+
+// SelectResourceEqual reports whether out is the companion table with columns resource created for a SELECT grant
+// on the table described by in. AWS may return the companion's column wildcard with excluded columns, so only the
+// table and the presence of the wildcard are compared.
+func SelectResourceEqual(in, out lakeformation.Resource) bool {
+	if in.TableWithColumns == nil || out.TableWithColumns == nil || out.TableWithColumns.ColumnWildcard == nil {
+		return false
+	}
+
+	// As in ResourceEqual, a copy takes the catalog ID AWS reports so that the caller's resource is left as is.
+	if in.TableWithColumns.CatalogId == nil {
+		in = ResourceWithCatalogID(in, out.TableWithColumns.CatalogId)
+	}
+
+	return aws.StringValue(in.TableWithColumns.CatalogId) == aws.StringValue(out.TableWithColumns.CatalogId) &&
+		aws.StringValue(in.TableWithColumns.DatabaseName) == aws.StringValue(out.TableWithColumns.DatabaseName) &&
+		aws.StringValue(in.TableWithColumns.Name) == aws.StringValue(out.TableWithColumns.Name)
+}
+
+func tableWithColumnsResourceEqual(in, out *lakeformation.TableWithColumnsResource) bool {
+	out = TableWithColumnsResourceForConfig(in, out)
+
+	if aws.StringValue(in.CatalogId) != aws.StringValue(out.CatalogId) ||
+		aws.StringValue(in.DatabaseName) != aws.StringValue(out.DatabaseName) ||
+		aws.StringValue(in.Name) != aws.StringValue(out.Name) {
+		return false
+	}
+
+	if !StringSetEqual(in.ColumnNames, out.ColumnNames) {
+		return false
+	}
+
+	if (in.ColumnWildcard == nil) != (out.ColumnWildcard == nil) {
+		return false
+	}
+
+	if in.ColumnWildcard != nil && !StringSetEqual(in.ColumnWildcard.ExcludedColumnNames, out.ColumnWildcard.ExcludedColumnNames) {
+		return false
+	}
+
+	return true
+}
+
+// TableWithColumnsResourceForConfig returns a copy of out keeping only the column representation used by in, when
+// AWS reports both column names and a column wildcard for the same entry. Without a configuration the explicit
+// column names are kept.
+func TableWithColumnsResourceForConfig(in, out *lakeformation.TableWithColumnsResource) *lakeformation.TableWithColumnsResource {
+	if out == nil || len(out.ColumnNames) == 0 || out.ColumnWildcard == nil {
+		return out
+	}
+
+	v := *out
+
+	if in != nil && in.ColumnWildcard != nil && len(in.ColumnNames) == 0 {
+		v.ColumnNames = nil
+	} else {
+		v.ColumnWildcard = nil
+	}
+
+	return &v
+}
+
+// dataLocationResourceEqual compares the data location ARNs exactly. A location ARN is never matched
+// by a prefix of it, e.g. a grant on arn:aws:s3:::bucket/data does not satisfy arn:aws:s3:::bucket/data/child.
+func dataLocationResourceEqual(in, out *lakeformation.DataLocationResource) bool {
+	return aws.StringValue(in.CatalogId) == aws.StringValue(out.CatalogId) &&
+		aws.StringValue(in.ResourceArn) == aws.StringValue(out.ResourceArn)
+}
+
+func databaseResourceEqual(in, out *lakeformation.DatabaseResource) bool {
+	return aws.StringValue(in.CatalogId) == aws.StringValue(out.CatalogId) &&
+		aws.StringValue(in.Name) == aws.StringValue(out.Name)
+}
+
+func tableResourceEqual(in, out *lakeformation.TableResource) bool {
+	if aws.StringValue(in.CatalogId) != aws.StringValue(out.CatalogId) ||
+		aws.StringValue(in.DatabaseName) != aws.StringValue(out.DatabaseName) ||
+		TableResourceIsWildcard(in) != TableResourceIsWildcard(out) {
+		return false
+	}
+
+	// A wildcard grant covers every table in the database and may be returned with a placeholder name.
+	if TableResourceIsWildcard(in) {
+		return true
+	}
+
+	return aws.StringValue(in.Name) == aws.StringValue(out.Name)
+}
+
+// WildcardTableMismatch reports whether in is a wildcard table resource and out is a grant on a single named table
+// in the same database.
+func WildcardTableMismatch(in, out *lakeformation.Resource) bool {
+	if in == nil || out == nil || in.Table == nil || out.Table == nil {
+		return false
+	}
+
+	if !TableResourceIsWildcard(in.Table) || TableResourceIsWildcard(out.Table) {
+		return false
+	}
+
+	if in.Table.CatalogId != nil && aws.StringValue(in.Table.CatalogId) != aws.StringValue(out.Table.CatalogId) {
+		return false
+	}
+
+	return aws.StringValue(in.Table.DatabaseName) == aws.StringValue(out.Table.DatabaseName)
+}
+
+// WildcardTableCovers reports whether in is a single named table resource and out is a wildcard grant on every
+// table in the same database, which covers the table without being a grant on it.
+func WildcardTableCovers(in, out *lakeformation.Resource) bool {
+	if in == nil || out == nil || in.Table == nil || out.Table == nil {
+		return false
+	}
+
+	if TableResourceIsWildcard(in.Table) || !TableResourceIsWildcard(out.Table) {
+		return false
+	}
+
+	if in.Table.CatalogId != nil && aws.StringValue(in.Table.CatalogId) != aws.StringValue(out.Table.CatalogId) {
+		return false
+	}
+
+	return aws.StringValue(in.Table.DatabaseName) == aws.StringValue(out.Table.DatabaseName)
+}
+
+// TableResourceIsWildcard reports whether the table resource represents every table in its database.
+func TableResourceIsWildcard(apiObject *lakeformation.TableResource) bool {
+	return apiObject.TableWildcard != nil || aws.StringValue(apiObject.Name) == TableNameAllTables
+}
+
+// StringSetEqual reports whether both lists contain the same values, regardless of order.
+func StringSetEqual(a, b []*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[aws.StringValue(v)]++
+	}
+
+	for _, v := range b {
+		counts[aws.StringValue(v)]--
+		if counts[aws.StringValue(v)] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
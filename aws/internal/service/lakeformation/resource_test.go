@@ -0,0 +1,551 @@
+package lakeformation_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/private/protocol/json/jsonutil"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func TestResourceEqual_columnSubsets(t *testing.T) {
+	tableWithColumns := func(columns ...string) *lakeformation.Resource {
+		return &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:    aws.String("123456789012"),
+				ColumnNames:  aws.StringSlice(columns),
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("table"),
+			},
+		}
+	}
+
+	// ListPermissions returns every column subset granted to the principal on the table.
+	entries := []*lakeformation.Resource{
+		tableWithColumns("event", "timestamp"),
+		tableWithColumns("value"),
+	}
+
+	configs := map[string]*lakeformation.Resource{
+		"first":  tableWithColumns("timestamp", "event"),
+		"second": tableWithColumns("value"),
+	}
+
+	expected := map[string]int{
+		"first":  0,
+		"second": 1,
+	}
+
+	for name, config := range configs {
+		var matches []int
+		for i, entry := range entries {
+			if tflakeformation.ResourceEqual(*config, *entry) {
+				matches = append(matches, i)
+			}
+		}
+
+		if len(matches) != 1 || matches[0] != expected[name] {
+			t.Errorf("%s: expected only entry %d to match, got %v", name, expected[name], matches)
+		}
+	}
+}
+
+func TestResourceEqual_nestedDataLocations(t *testing.T) {
+	dataLocation := func(resourceArn string) *lakeformation.Resource {
+		return &lakeformation.Resource{
+			DataLocation: &lakeformation.DataLocationResource{
+				CatalogId:   aws.String("123456789012"),
+				ResourceArn: aws.String(resourceArn),
+			},
+		}
+	}
+
+	// ListPermissions returns the grants on every prefix in the hierarchy for the principal.
+	entries := []*lakeformation.Resource{
+		dataLocation("arn:aws:s3:::bucket"),                //lintignore:AWSAT005
+		dataLocation("arn:aws:s3:::bucket/data"),           //lintignore:AWSAT005
+		dataLocation("arn:aws:s3:::bucket/data/child"),     //lintignore:AWSAT005
+		dataLocation("arn:aws:s3:::bucket/data-other"),     //lintignore:AWSAT005
+		dataLocation("arn:aws:s3:::bucket/data/child/sub"), //lintignore:AWSAT005
+	}
+
+	configs := map[string]*lakeformation.Resource{
+		"bucket": dataLocation("arn:aws:s3:::bucket"),            //lintignore:AWSAT005
+		"parent": dataLocation("arn:aws:s3:::bucket/data"),       //lintignore:AWSAT005
+		"child":  dataLocation("arn:aws:s3:::bucket/data/child"), //lintignore:AWSAT005
+	}
+
+	expected := map[string]int{
+		"bucket": 0,
+		"parent": 1,
+		"child":  2,
+	}
+
+	for name, config := range configs {
+		var matches []int
+		for i, entry := range entries {
+			if tflakeformation.ResourceEqual(*config, *entry) {
+				matches = append(matches, i)
+			}
+		}
+
+		if len(matches) != 1 || matches[0] != expected[name] {
+			t.Errorf("%s: expected only entry %d to match, got %v", name, expected[name], matches)
+		}
+	}
+}
+
+func TestResourceEqual(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		In       *lakeformation.Resource
+		Out      *lakeformation.Resource
+		Expected bool
+	}{
+		{
+			Name: "table config with database grant sharing database name",
+			In: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("deleted_table"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "database config with database grant",
+			In: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "table config with table grant",
+			In: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "iceberg table config with iceberg table grant",
+			In: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("lakehouse"),
+					Name:         aws.String("iceberg_events"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("lakehouse"),
+					Name:          aws.String("iceberg_events"),
+					TableWildcard: nil,
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "table config with table wildcard grant",
+			In: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("lakehouse"),
+					Name:         aws.String("iceberg_events"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("lakehouse"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "database config with database grant in another catalog",
+			In: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+			Out: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("210987654321"),
+					Name:      aws.String("db"),
+				},
+			},
+			Expected: false,
+		},
+		{
+			// e.g. a resource type the SDK does not decode yet
+			Name:     "unknown resource type",
+			In:       &lakeformation.Resource{},
+			Out:      &lakeformation.Resource{},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := tflakeformation.ResourceEqual(*testCase.In, *testCase.Out)
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestResourceEqual_unknownResponseFields(t *testing.T) {
+	// A response after Lake Formation added fields to the resource representations.
+	response := `{
+  "PrincipalResourcePermissions": [
+    {
+      "Permissions": ["ALTER"],
+      "Principal": {"DataLakePrincipalIdentifier": "arn:aws:iam::123456789012:role/test"},
+      "Resource": {
+        "Database": {"CatalogId": "123456789012", "Name": "db", "DatabaseVersion": "2"}
+      }
+    },
+    {
+      "Permissions": ["SELECT"],
+      "Principal": {"DataLakePrincipalIdentifier": "arn:aws:iam::123456789012:role/test"},
+      "Resource": {
+        "Table": {"CatalogId": "123456789012", "DatabaseName": "db", "Name": "table", "TableFormat": {"Type": "ICEBERG"}}
+      }
+    },
+    {
+      "Permissions": ["DESCRIBE"],
+      "Principal": {"DataLakePrincipalIdentifier": "arn:aws:iam::123456789012:role/test"},
+      "Resource": {
+        "FutureResource": {"CatalogId": "123456789012", "Name": "db"}
+      }
+    }
+  ]
+}` //lintignore:AWSAT003,AWSAT005
+
+	output := &lakeformation.ListPermissionsOutput{}
+	if err := jsonutil.UnmarshalJSON(output, strings.NewReader(response)); err != nil {
+		t.Fatalf("error decoding response: %s", err)
+	}
+
+	testCases := []struct {
+		Name     string
+		In       *lakeformation.Resource
+		Expected []bool
+	}{
+		{
+			Name: "database",
+			In: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+			Expected: []bool{true, false, false},
+		},
+		{
+			Name: "table",
+			In: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Expected: []bool{false, true, false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			for i, permission := range output.PrincipalResourcePermissions {
+				if got := tflakeformation.ResourceEqual(*testCase.In, *permission.Resource); got != testCase.Expected[i] {
+					t.Errorf("entry %d: got %t, expected %t", i, got, testCase.Expected[i])
+				}
+			}
+
+			// The configured resource keeps no catalog ID, so it can still match entries in other catalogs.
+			if v := tflakeformation.ResourceCatalogID(testCase.In); v != nil {
+				t.Errorf("expected the configured resource not to be modified, got catalog ID %s", aws.StringValue(v))
+			}
+		})
+	}
+}
+
+func TestSelectResourceEqual(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Out      *lakeformation.Resource
+		Expected bool
+	}{
+		{
+			Name: "column wildcard",
+			Out: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("table"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "column wildcard with exclusions",
+			Out: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId: aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{
+						ExcludedColumnNames: aws.StringSlice([]string{"ssn"}),
+					},
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "named columns",
+			Out: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:    aws.String("123456789012"),
+					ColumnNames:  aws.StringSlice([]string{"id"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "other table",
+			Out: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("other"),
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			in := lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("table"),
+				},
+			}
+
+			got := tflakeformation.SelectResourceEqual(in, *testCase.Out)
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+
+			if v := in.TableWithColumns.CatalogId; v != nil {
+				t.Errorf("expected the configured resource not to be modified, got catalog ID %s", aws.StringValue(v))
+			}
+		})
+	}
+}
+
+func TestSharedResourceEqual(t *testing.T) {
+	ownerCatalogId := "111122223333"
+	sharerCatalogId := "123456789012"
+
+	in := func() lakeformation.Resource {
+		return lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    aws.String(ownerCatalogId),
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("table"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name             string
+		Out              *lakeformation.Resource
+		GrantorCatalogId string
+		Expected         bool
+	}{
+		{
+			Name: "sharer catalog ID echoed",
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(sharerCatalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			GrantorCatalogId: sharerCatalogId,
+			Expected:         true,
+		},
+		{
+			Name: "unrelated catalog ID",
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("444455556666"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			GrantorCatalogId: sharerCatalogId,
+			Expected:         false,
+		},
+		{
+			Name: "sharer catalog ID with other table",
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(sharerCatalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("other"),
+				},
+			},
+			GrantorCatalogId: sharerCatalogId,
+			Expected:         false,
+		},
+		{
+			Name: "unknown grantor",
+			Out: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(sharerCatalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			apiObject := in()
+
+			if tflakeformation.ResourceEqual(apiObject, *testCase.Out) && testCase.Expected {
+				t.Fatal("expected regular comparison to fail across catalogs")
+			}
+
+			got := tflakeformation.SharedResourceEqual(apiObject, *testCase.Out, testCase.GrantorCatalogId)
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+
+			if v := aws.StringValue(apiObject.Table.CatalogId); v != ownerCatalogId {
+				t.Errorf("expected configured catalog ID to be left as %s, got %s", ownerCatalogId, v)
+			}
+		})
+	}
+}
+
+func TestResourceWithEffectiveCatalogID(t *testing.T) {
+	// Configuration sets the current account explicitly while ListPermissions omits the catalog ID.
+	in := lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			CatalogId: aws.String("123456789012"),
+			Name:      aws.String("db"),
+		},
+	}
+	out := lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			Name: aws.String("db"),
+		},
+	}
+
+	if tflakeformation.ResourceEqual(in, out) {
+		t.Fatal("expected comparison against a response without catalog ID to fail before normalization")
+	}
+
+	normalized := tflakeformation.ResourceWithEffectiveCatalogID(out, "123456789012")
+
+	if !tflakeformation.ResourceEqual(in, normalized) {
+		t.Error("expected explicit catalog ID to match the normalized response")
+	}
+
+	if out.Database.CatalogId != nil {
+		t.Error("expected the response not to be modified")
+	}
+
+	if v := tflakeformation.ResourceWithEffectiveCatalogID(normalized, "111122223333"); aws.StringValue(v.Database.CatalogId) != "123456789012" {
+		t.Errorf("expected existing catalog ID to be kept, got %s", aws.StringValue(v.Database.CatalogId))
+	}
+
+	catalog := lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}}
+
+	if v := tflakeformation.ResourceWithEffectiveCatalogID(catalog, "123456789012"); !reflect.DeepEqual(v, catalog) {
+		t.Errorf("expected catalog resource to be unchanged, got %v", v)
+	}
+}
+
+func TestWildcardTableMismatch(t *testing.T) {
+	wildcard := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName:  aws.String("db"),
+			TableWildcard: &lakeformation.TableWildcard{},
+		},
+	}
+	named := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+		},
+	}
+
+	if tflakeformation.ResourceEqual(*wildcard, *named) {
+		t.Error("expected wildcard configuration not to match a named table grant")
+	}
+
+	if !tflakeformation.WildcardTableMismatch(wildcard, named) {
+		t.Error("expected a named table grant for a wildcard configuration to be reported")
+	}
+
+	if tflakeformation.WildcardTableMismatch(named, wildcard) {
+		t.Error("expected no report for a named table configuration")
+	}
+
+	otherDatabase := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("other"),
+			Name:         aws.String("table"),
+		},
+	}
+
+	if tflakeformation.WildcardTableMismatch(wildcard, otherDatabase) {
+		t.Error("expected no report for a table in another database")
+	}
+}
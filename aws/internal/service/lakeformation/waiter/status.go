@@ -0,0 +1,35 @@
+package waiter
+
+import (
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation/finder"
+)
+
+const (
+	ResourceStatusNotRegistered = "NotRegistered"
+	ResourceStatusRegistered    = "Registered"
+	ResourceStatusUnknown       = "Unknown"
+)
+
+// ResourceStatus fetches the registered Resource and its registration status
+func ResourceStatus(conn *lakeformation.LakeFormation, arn string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := finder.ResourceByARN(conn, arn)
+
+		if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+			return nil, ResourceStatusNotRegistered, nil
+		}
+
+		if err != nil {
+			return nil, ResourceStatusUnknown, err
+		}
+
+		if output == nil {
+			return nil, ResourceStatusNotRegistered, nil
+		}
+
+		return output, ResourceStatusRegistered, nil
+	}
+}
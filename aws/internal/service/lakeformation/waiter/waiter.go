@@ -0,0 +1,31 @@
+package waiter
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	// Maximum amount of time to wait for a Resource registration to become visible
+	ResourceRegisteredTimeout = 2 * time.Minute
+)
+
+// ResourceRegistered waits for a Resource to return Registered
+func ResourceRegistered(conn *lakeformation.LakeFormation, arn string) (*lakeformation.ResourceInfo, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ResourceStatusNotRegistered},
+		Target:  []string{ResourceStatusRegistered},
+		Refresh: ResourceStatus(conn, arn),
+		Timeout: ResourceRegisteredTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForState()
+
+	if output, ok := outputRaw.(*lakeformation.ResourceInfo); ok {
+		return output, err
+	}
+
+	return nil, err
+}
@@ -320,6 +320,7 @@ func Provider() *schema.Provider {
 			"aws_kms_secrets":                                dataSourceAwsKmsSecrets(),
 			"aws_lakeformation_data_lake_settings":           dataSourceAwsLakeFormationDataLakeSettings(),
 			"aws_lakeformation_permissions":                  dataSourceAwsLakeFormationPermissions(),
+			"aws_lakeformation_principal_permissions":        dataSourceAwsLakeFormationPrincipalPermissions(),
 			"aws_lakeformation_resource":                     dataSourceAwsLakeFormationResource(),
 			"aws_lambda_alias":                               dataSourceAwsLambdaAlias(),
 			"aws_lambda_code_signing_config":                 dataSourceAwsLambdaCodeSigningConfig(),
@@ -810,7 +811,9 @@ func Provider() *schema.Provider {
 			"aws_kms_grant":                                           resourceAwsKmsGrant(),
 			"aws_kms_key":                                             resourceAwsKmsKey(),
 			"aws_kms_ciphertext":                                      resourceAwsKmsCiphertext(),
+			"aws_lakeformation_batch_permissions":                     resourceAwsLakeFormationBatchPermissions(),
 			"aws_lakeformation_data_lake_settings":                    resourceAwsLakeFormationDataLakeSettings(),
+			"aws_lakeformation_database_default_permissions":          resourceAwsLakeFormationDatabaseDefaultPermissions(),
 			"aws_lakeformation_permissions":                           resourceAwsLakeFormationPermissions(),
 			"aws_lakeformation_resource":                              resourceAwsLakeFormationResource(),
 			"aws_lambda_alias":                                        resourceAwsLambdaAlias(),
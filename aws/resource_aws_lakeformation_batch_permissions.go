@@ -0,0 +1,700 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	iamwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/iam/waiter"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func resourceAwsLakeFormationBatchPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsLakeFormationBatchPermissionsCreate,
+		ReadContext:   resourceAwsLakeFormationBatchPermissionsRead,
+		UpdateContext: resourceAwsLakeFormationBatchPermissionsUpdate,
+		DeleteContext: resourceAwsLakeFormationBatchPermissionsDelete,
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsLakeFormationBatchPermissionsValidateEntries,
+			resourceAwsLakeFormationBatchPermissionsCatalogIdDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"entry": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"catalog_resource": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"data_location": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     lakeFormationDataLocationResourceElem(),
+						},
+						"database": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     lakeFormationDatabaseResourceElem(),
+						},
+						"permissions": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(lakeformation.Permission_Values(), false),
+							},
+						},
+						"permissions_with_grant_option": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(lakeformation.Permission_Values(), false),
+							},
+						},
+						"principal": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePrincipal,
+						},
+						"table": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     lakeFormationTableResourceElem(),
+						},
+						"table_with_columns": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     lakeFormationTableWithColumnsResourceElem(),
+						},
+					},
+				},
+			},
+			"rollback_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceAwsLakeFormationBatchPermissionsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogID := d.Get("catalog_id").(string)
+	grantorCatalogId := lakeFormationBatchPermissionsGrantorCatalogId(d, meta)
+	tfList := d.Get("entry").([]interface{})
+	entries := expandLakeFormationBatchPermissionsEntries(tfList, grantorCatalogId)
+
+	if err := lakeFormationBatchPermissionsDuplicateEntriesError(entries); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Lake Formation Batch Permissions: %w", err))
+	}
+
+	granted, err := lakeFormationBatchGrantPermissions(ctx, conn, catalogID, entries, d.Get("rollback_on_failure").(bool))
+
+	if err != nil {
+		// Without rollback, the entries that were granted are kept in state so that they are revoked on destroy.
+		if len(granted) > 0 {
+			d.SetId(resource.UniqueId())
+			d.Set("entry", lakeFormationBatchPermissionsFilterEntries(tfList, grantorCatalogId, granted))
+		}
+
+		return diag.FromErr(fmt.Errorf("error creating Lake Formation Batch Permissions: %w", err))
+	}
+
+	d.SetId(resource.UniqueId())
+
+	return resourceAwsLakeFormationBatchPermissionsRead(ctx, d, meta)
+}
+
+// resourceAwsLakeFormationBatchPermissionsRead lists the permissions of each entry, so that permissions changed
+// outside of Terraform show up as a difference. Entries that no longer hold any permission are dropped from state.
+func resourceAwsLakeFormationBatchPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogID := d.Get("catalog_id").(string)
+	grantorCatalogId := lakeFormationBatchPermissionsGrantorCatalogId(d, meta)
+
+	var tfList []interface{}
+
+	for _, tfMapRaw := range d.Get("entry").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		entry := expandLakeFormationBatchPermissionsEntry(tfMap, grantorCatalogId)
+		principalResourcePermissions, err := lakeFormationBatchPermissionsEntryPermissions(ctx, conn, catalogID, grantorCatalogId, entry)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading Lake Formation Batch Permissions (%s) entry (%s): %w", d.Id(), aws.StringValue(entry.Id), err))
+		}
+
+		if len(principalResourcePermissions) == 0 && d.IsNewResource() {
+			return diag.FromErr(fmt.Errorf("error reading Lake Formation Batch Permissions (%s): entry (%s) not found", d.Id(), aws.StringValue(entry.Id)))
+		}
+
+		if len(principalResourcePermissions) == 0 {
+			log.Printf("[WARN] Lake Formation Batch Permissions (%s) entry (%s) not found, removing it from state", d.Id(), aws.StringValue(entry.Id))
+			continue
+		}
+
+		tfList = append(tfList, flattenLakeFormationBatchPermissionsEntry(tfMap, lakeFormationResourceTypeOf(entry.Resource), principalResourcePermissions))
+	}
+
+	if len(tfList) == 0 {
+		log.Printf("[WARN] Lake Formation Batch Permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("entry", tfList); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting entry: %w", err))
+	}
+
+	return nil
+}
+
+// resourceAwsLakeFormationBatchPermissionsUpdate revokes the removed entries and grants the added ones. An entry
+// whose permissions changed is both. Entries that did not change are left alone, so rolling back a failed grant
+// never revokes grants made by an earlier apply.
+func resourceAwsLakeFormationBatchPermissionsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogID := d.Get("catalog_id").(string)
+	grantorCatalogId := lakeFormationBatchPermissionsGrantorCatalogId(d, meta)
+
+	if d.HasChange("entry") {
+		o, n := d.GetChange("entry")
+		oldList, newList := o.([]interface{}), n.([]interface{})
+		oldEntries := expandLakeFormationBatchPermissionsEntries(oldList, grantorCatalogId)
+		newEntries := expandLakeFormationBatchPermissionsEntries(newList, grantorCatalogId)
+
+		if err := lakeFormationBatchPermissionsDuplicateEntriesError(newEntries); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Lake Formation Batch Permissions (%s): %w", d.Id(), err))
+		}
+
+		revoke := lakeFormationBatchPermissionsEntriesDifference(oldEntries, newEntries)
+		revoked, err := lakeFormationBatchRevokePermissions(ctx, conn, catalogID, revoke)
+
+		if err != nil {
+			d.Set("entry", lakeFormationBatchPermissionsFilterEntries(oldList, grantorCatalogId, lakeFormationBatchPermissionsEntriesDifference(oldEntries, revoked)))
+
+			return diag.FromErr(fmt.Errorf("error updating Lake Formation Batch Permissions (%s): %w", d.Id(), err))
+		}
+
+		grant := lakeFormationBatchPermissionsEntriesDifference(newEntries, oldEntries)
+		granted, err := lakeFormationBatchGrantPermissions(ctx, conn, catalogID, grant, d.Get("rollback_on_failure").(bool))
+
+		if err != nil {
+			// The entries that did not change are still granted, as are those granted without rollback.
+			failed := lakeFormationBatchPermissionsEntriesDifference(grant, granted)
+			d.Set("entry", lakeFormationBatchPermissionsFilterEntries(newList, grantorCatalogId, lakeFormationBatchPermissionsEntriesDifference(newEntries, failed)))
+
+			return diag.FromErr(fmt.Errorf("error updating Lake Formation Batch Permissions (%s): %w", d.Id(), err))
+		}
+	}
+
+	return resourceAwsLakeFormationBatchPermissionsRead(ctx, d, meta)
+}
+
+func resourceAwsLakeFormationBatchPermissionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogID := d.Get("catalog_id").(string)
+	grantorCatalogId := lakeFormationBatchPermissionsGrantorCatalogId(d, meta)
+	tfList := d.Get("entry").([]interface{})
+	entries := expandLakeFormationBatchPermissionsEntries(tfList, grantorCatalogId)
+
+	revoked, err := lakeFormationBatchRevokePermissions(ctx, conn, catalogID, entries)
+
+	if err != nil {
+		// Only the entries that are still granted are kept in state.
+		d.Set("entry", lakeFormationBatchPermissionsFilterEntries(tfList, grantorCatalogId, lakeFormationBatchPermissionsEntriesDifference(entries, revoked)))
+
+		return diag.FromErr(fmt.Errorf("error deleting Lake Formation Batch Permissions (%s): %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// resourceAwsLakeFormationBatchPermissionsValidateEntries returns an error unless each entry configures exactly one
+// resource.
+func resourceAwsLakeFormationBatchPermissionsValidateEntries(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for i, tfMapRaw := range diff.Get("entry").([]interface{}) {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		if err := lakeFormationValidateBatchPermissionsEntry(tfMap); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceAwsLakeFormationBatchPermissionsCatalogIdDiff drops catalog ID changes between an unset value and the
+// caller's account ID, for the resource and for each entry.
+func resourceAwsLakeFormationBatchPermissionsCatalogIdDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"catalog_id"}
+
+	for i := range diff.Get("entry").([]interface{}) {
+		for _, block := range []string{"data_location", "database", "table", "table_with_columns"} {
+			keys = append(keys, fmt.Sprintf("entry.%d.%s.0.catalog_id", i, block))
+		}
+	}
+
+	return lakeFormationClearEquivalentCatalogIds(diff, meta.(*AWSClient).accountid, keys)
+}
+
+// lakeFormationBatchPermissionsGrantorCatalogId returns the catalog the entries are granted in.
+func lakeFormationBatchPermissionsGrantorCatalogId(d *schema.ResourceData, meta interface{}) string {
+	if v, ok := d.GetOk("catalog_id"); ok {
+		return v.(string)
+	}
+
+	return meta.(*AWSClient).accountid
+}
+
+// lakeFormationBatchGrantPermissions grants all entries in a single call and returns the entries that remain
+// granted. When some entries fail and rollback is requested, the entries that were granted are revoked again on a
+// best-effort basis.
+func lakeFormationBatchGrantPermissions(ctx context.Context, conn *lakeformation.LakeFormation, catalogID string, entries []*lakeformation.BatchPermissionsRequestEntry, rollback bool) ([]*lakeformation.BatchPermissionsRequestEntry, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	input := &lakeformation.BatchGrantPermissionsInput{
+		Entries: lakeFormationBatchPermissionsRequestEntries(entries),
+	}
+
+	if catalogID != "" {
+		input.CatalogId = aws.String(catalogID)
+	}
+
+	var output *lakeformation.BatchGrantPermissionsOutput
+	err := resource.RetryContext(ctx, iamwaiter.PropagationTimeout, lakeFormationRetryFunc(isLakeFormationRetryableError, func() error {
+		var err error
+		output, err = conn.BatchGrantPermissionsWithContext(ctx, input)
+		return err
+	}))
+
+	if isResourceTimeoutError(err) {
+		output, err = conn.BatchGrantPermissionsWithContext(ctx, input)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.Failures) == 0 {
+		return entries, nil
+	}
+
+	failures := lakeFormationBatchPermissionsResponseFailures(entries, output.Failures)
+	err = lakeFormationBatchPermissionsFailuresError(failures)
+	granted := lakeFormationBatchPermissionsSucceededEntries(entries, failures)
+
+	if !rollback || len(granted) == 0 {
+		return granted, err
+	}
+
+	log.Printf("[DEBUG] Rolling back %d granted Lake Formation Batch Permissions entries", len(granted))
+
+	revoked, rollbackErr := lakeFormationBatchRevokePermissions(ctx, conn, catalogID, granted)
+
+	if rollbackErr != nil {
+		return lakeFormationBatchPermissionsEntriesDifference(granted, revoked), multierror.Append(err, fmt.Errorf("error rolling back granted entries: %w", rollbackErr))
+	}
+
+	return nil, err
+}
+
+// lakeFormationBatchRevokePermissions revokes all entries in a single call and returns the entries that were
+// revoked.
+func lakeFormationBatchRevokePermissions(ctx context.Context, conn *lakeformation.LakeFormation, catalogID string, entries []*lakeformation.BatchPermissionsRequestEntry) ([]*lakeformation.BatchPermissionsRequestEntry, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	input := &lakeformation.BatchRevokePermissionsInput{
+		Entries: lakeFormationBatchPermissionsRequestEntries(entries),
+	}
+
+	if catalogID != "" {
+		input.CatalogId = aws.String(catalogID)
+	}
+
+	var output *lakeformation.BatchRevokePermissionsOutput
+	err := resource.RetryContext(ctx, iamwaiter.PropagationTimeout, lakeFormationRetryFunc(isLakeFormationRetryableError, func() error {
+		var err error
+		output, err = conn.BatchRevokePermissionsWithContext(ctx, input)
+		return err
+	}))
+
+	if isResourceTimeoutError(err) {
+		output, err = conn.BatchRevokePermissionsWithContext(ctx, input)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.Failures) == 0 {
+		return entries, nil
+	}
+
+	failures := lakeFormationBatchPermissionsResponseFailures(entries, output.Failures)
+
+	return lakeFormationBatchPermissionsSucceededEntries(entries, failures), lakeFormationBatchPermissionsFailuresError(failures)
+}
+
+// lakeFormationBatchPermissionsEntryPermissions returns the aggregated permissions AWS reports for the principal and
+// resource of an entry.
+func lakeFormationBatchPermissionsEntryPermissions(ctx context.Context, conn *lakeformation.LakeFormation, catalogID, grantorCatalogId string, entry *lakeformation.BatchPermissionsRequestEntry) ([]*lakeformation.PrincipalResourcePermissions, error) {
+	resourceType := lakeFormationResourceTypeOf(entry.Resource)
+
+	input := &lakeformation.ListPermissionsInput{
+		Principal:    entry.Principal,
+		Resource:     entry.Resource,
+		ResourceType: aws.String(tflakeformation.ListPermissionsResourceType(resourceType)),
+	}
+
+	if catalogID != "" {
+		input.CatalogId = aws.String(catalogID)
+	}
+
+	// ListPermissions does not support getting privileges by tables with columns.
+	if v := entry.Resource.TableWithColumns; v != nil {
+		input.Resource = &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    v.CatalogId,
+				DatabaseName: v.DatabaseName,
+				Name:         v.Name,
+			},
+		}
+	}
+
+	// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
+	var selectPermissionsResource *lakeformation.Resource
+	if v := entry.Resource.Table; v != nil && !tflakeformation.TableResourceIsWildcard(v) {
+		selectPermissionsResource = &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:      v.CatalogId,
+				DatabaseName:   v.DatabaseName,
+				Name:           v.Name,
+				ColumnWildcard: &lakeformation.ColumnWildcard{},
+			},
+		}
+	}
+
+	id := aws.StringValue(entry.Id)
+	match := func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch(id, entry.Resource, selectPermissionsResource, grantorCatalogId, permission)
+	}
+	collector := newLakeFormationPermissionsCollector(match)
+
+	err := resource.RetryContext(ctx, iamwaiter.PropagationTimeout, lakeFormationRetryFunc(isLakeFormationListPermissionsRetryableError, func() error {
+		collector = newLakeFormationPermissionsCollector(match)
+		return conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}))
+
+	if isResourceTimeoutError(err) {
+		collector = newLakeFormationPermissionsCollector(match)
+		err = conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tflakeformation.PermissionsAggregate(collector.matches), nil
+}
+
+func expandLakeFormationBatchPermissionsEntries(tfList []interface{}, grantorCatalogId string) []*lakeformation.BatchPermissionsRequestEntry {
+	apiObjects := make([]*lakeformation.BatchPermissionsRequestEntry, 0, len(tfList))
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiObjects = append(apiObjects, expandLakeFormationBatchPermissionsEntry(tfMap, grantorCatalogId))
+	}
+
+	return apiObjects
+}
+
+// expandLakeFormationBatchPermissionsEntry returns the entry described by a configuration block. Its ID is the
+// canonical ID of the principal and resource, so that it does not depend on the position of the block.
+func expandLakeFormationBatchPermissionsEntry(tfMap map[string]interface{}, grantorCatalogId string) *lakeformation.BatchPermissionsRequestEntry {
+	principal := tfMap["principal"].(string)
+	res := expandLakeFormationResourceFromMap(tfMap)
+
+	apiObject := &lakeformation.BatchPermissionsRequestEntry{
+		Id: aws.String(tflakeformation.PermissionsCreateID(principal, grantorCatalogId, res)),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(principal),
+		},
+		Resource: res,
+	}
+
+	if v, ok := tfMap["permissions"].(*schema.Set); ok && v.Len() > 0 {
+		apiObject.Permissions = expandStringSet(v)
+	}
+
+	if v, ok := tfMap["permissions_with_grant_option"].(*schema.Set); ok && v.Len() > 0 {
+		apiObject.PermissionsWithGrantOption = expandStringSet(v)
+	}
+
+	return apiObject
+}
+
+// flattenLakeFormationBatchPermissionsEntry returns the entry configuration block with the permissions reported by
+// AWS.
+func flattenLakeFormationBatchPermissionsEntry(tfMap map[string]interface{}, resourceType string, apiObjects []*lakeformation.PrincipalResourcePermissions) map[string]interface{} {
+	entry := make(map[string]interface{}, len(tfMap))
+
+	for k, v := range tfMap {
+		entry[k] = v
+	}
+
+	permissions := flattenLakeFormationPermissions(apiObjects)
+	grantPermissions := flattenLakeFormationGrantPermissions(apiObjects)
+	permissions = tflakeformation.PermissionsWithExpandedAll(resourceType, permissions, grantPermissions)
+	grantPermissions = tflakeformation.PermissionsWithExpandedAll(resourceType, grantPermissions, permissions)
+
+	configured, _ := tfMap["permissions"].(*schema.Set)
+	configuredGrant, _ := tfMap["permissions_with_grant_option"].(*schema.Set)
+
+	entry["permissions"] = flattenStringSet(aws.StringSlice(lakeFormationCollapseBroadPermissions(permissions, configured)))
+	entry["permissions_with_grant_option"] = flattenStringSet(aws.StringSlice(lakeFormationCollapseBroadPermissions(grantPermissions, configuredGrant)))
+
+	return entry
+}
+
+// expandLakeFormationResourceFromMap returns the Lake Formation resource described by a configuration block
+// holding the same resource arguments as aws_lakeformation_permissions.
+func expandLakeFormationResourceFromMap(tfMap map[string]interface{}) *lakeformation.Resource {
+	apiObject := &lakeformation.Resource{}
+
+	if v, ok := tfMap["catalog_resource"].(bool); ok && v {
+		apiObject.Catalog = &lakeformation.CatalogResource{}
+	} else if v, ok := tfMap["data_location"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		apiObject.DataLocation = expandLakeFormationDataLocationResource(v[0].(map[string]interface{}))
+	} else if v, ok := tfMap["database"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		apiObject.Database = expandLakeFormationDatabaseResource(v[0].(map[string]interface{}))
+	} else if v, ok := tfMap["table"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		apiObject.Table = expandLakeFormationTableResource(v[0].(map[string]interface{}))
+	} else if v, ok := tfMap["table_with_columns"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		apiObject.TableWithColumns = expandLakeFormationTableWithColumnsResource(v[0].(map[string]interface{}))
+	}
+
+	return apiObject
+}
+
+// lakeFormationValidateBatchPermissionsEntry returns an error unless the entry configures exactly one resource.
+func lakeFormationValidateBatchPermissionsEntry(tfMap map[string]interface{}) error {
+	count := 0
+
+	if v, ok := tfMap["catalog_resource"].(bool); ok && v {
+		count++
+	}
+
+	for _, key := range []string{"data_location", "database", "table", "table_with_columns"} {
+		if v, ok := tfMap[key].([]interface{}); ok && len(v) > 0 {
+			count++
+		}
+	}
+
+	if count != 1 {
+		return fmt.Errorf("exactly one of catalog_resource, data_location, database, table, table_with_columns must be configured")
+	}
+
+	return nil
+}
+
+// lakeFormationBatchPermissionsEntriesDifference returns the entries of a that are not in b. Entries are the same
+// when they have the same ID, identifying the principal and resource, and grant the same permissions.
+func lakeFormationBatchPermissionsEntriesDifference(a, b []*lakeformation.BatchPermissionsRequestEntry) []*lakeformation.BatchPermissionsRequestEntry {
+	entries := make(map[string]*lakeformation.BatchPermissionsRequestEntry, len(b))
+
+	for _, entry := range b {
+		entries[aws.StringValue(entry.Id)] = entry
+	}
+
+	var difference []*lakeformation.BatchPermissionsRequestEntry
+
+	for _, entry := range a {
+		if v, ok := entries[aws.StringValue(entry.Id)]; !ok || !lakeFormationBatchPermissionsEntryPermissionsEqual(entry, v) {
+			difference = append(difference, entry)
+		}
+	}
+
+	return difference
+}
+
+func lakeFormationBatchPermissionsEntryPermissionsEqual(a, b *lakeformation.BatchPermissionsRequestEntry) bool {
+	return tflakeformation.StringSetEqual(a.Permissions, b.Permissions) && tflakeformation.StringSetEqual(a.PermissionsWithGrantOption, b.PermissionsWithGrantOption)
+}
+
+// lakeFormationBatchPermissionsFilterEntries returns the entry configuration blocks of tfList that describe one of
+// entries, in configuration order.
+func lakeFormationBatchPermissionsFilterEntries(tfList []interface{}, grantorCatalogId string, entries []*lakeformation.BatchPermissionsRequestEntry) []interface{} {
+	var filtered []interface{}
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		entry := expandLakeFormationBatchPermissionsEntry(tfMap, grantorCatalogId)
+
+		if len(lakeFormationBatchPermissionsEntriesDifference([]*lakeformation.BatchPermissionsRequestEntry{entry}, entries)) == 0 {
+			filtered = append(filtered, tfMap)
+		}
+	}
+
+	return filtered
+}
+
+// lakeFormationBatchPermissionsDuplicateEntriesError returns an error when several entries grant on the same
+// principal and resource, which could not be told apart when reading or updating them.
+func lakeFormationBatchPermissionsDuplicateEntriesError(entries []*lakeformation.BatchPermissionsRequestEntry) error {
+	ids := make(map[string]bool, len(entries))
+
+	for _, entry := range entries {
+		id := aws.StringValue(entry.Id)
+
+		if ids[id] {
+			return fmt.Errorf("entry (%s) is configured more than once", id)
+		}
+
+		ids[id] = true
+	}
+
+	return nil
+}
+
+// lakeFormationBatchPermissionsRequestEntries returns copies of the entries identified by their position, as request
+// entry IDs are limited to 255 characters while entry IDs can be longer, e.g. for a table with many columns.
+func lakeFormationBatchPermissionsRequestEntries(entries []*lakeformation.BatchPermissionsRequestEntry) []*lakeformation.BatchPermissionsRequestEntry {
+	requestEntries := make([]*lakeformation.BatchPermissionsRequestEntry, 0, len(entries))
+
+	for i, entry := range entries {
+		requestEntry := *entry
+		requestEntry.Id = aws.String(strconv.Itoa(i))
+		requestEntries = append(requestEntries, &requestEntry)
+	}
+
+	return requestEntries
+}
+
+// lakeFormationBatchPermissionsResponseFailures returns the failures with their request entries replaced by the
+// entries the request was made from.
+func lakeFormationBatchPermissionsResponseFailures(entries []*lakeformation.BatchPermissionsRequestEntry, failures []*lakeformation.BatchPermissionsFailureEntry) []*lakeformation.BatchPermissionsFailureEntry {
+	apiObjects := make([]*lakeformation.BatchPermissionsFailureEntry, 0, len(failures))
+
+	for _, failure := range failures {
+		if failure == nil {
+			continue
+		}
+
+		apiObject := *failure
+
+		if failure.RequestEntry != nil {
+			if i, err := strconv.Atoi(aws.StringValue(failure.RequestEntry.Id)); err == nil && i >= 0 && i < len(entries) {
+				apiObject.RequestEntry = entries[i]
+			}
+		}
+
+		apiObjects = append(apiObjects, &apiObject)
+	}
+
+	return apiObjects
+}
+
+// lakeFormationBatchPermissionsSucceededEntries returns the entries that are not reported as failures.
+func lakeFormationBatchPermissionsSucceededEntries(entries []*lakeformation.BatchPermissionsRequestEntry, failures []*lakeformation.BatchPermissionsFailureEntry) []*lakeformation.BatchPermissionsRequestEntry {
+	failed := make(map[string]bool, len(failures))
+
+	for _, failure := range failures {
+		if failure == nil || failure.RequestEntry == nil {
+			continue
+		}
+
+		failed[aws.StringValue(failure.RequestEntry.Id)] = true
+	}
+
+	var succeeded []*lakeformation.BatchPermissionsRequestEntry
+
+	for _, entry := range entries {
+		if !failed[aws.StringValue(entry.Id)] {
+			succeeded = append(succeeded, entry)
+		}
+	}
+
+	return succeeded
+}
+
+func lakeFormationBatchPermissionsFailuresError(failures []*lakeformation.BatchPermissionsFailureEntry) error {
+	var errs *multierror.Error
+
+	for _, failure := range failures {
+		if failure == nil {
+			continue
+		}
+
+		var id string
+		if failure.RequestEntry != nil {
+			id = aws.StringValue(failure.RequestEntry.Id)
+		}
+
+		if failure.Error == nil {
+			errs = multierror.Append(errs, fmt.Errorf("entry (%s): unknown error", id))
+			continue
+		}
+
+		errs = multierror.Append(errs, fmt.Errorf("entry (%s): %s: %s", id, aws.StringValue(failure.Error.ErrorCode), aws.StringValue(failure.Error.ErrorMessage)))
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,653 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLakeFormationBatchGrantPermissionsRollback(t *testing.T) {
+	entries := []*lakeformation.BatchPermissionsRequestEntry{
+		{
+			Id:          aws.String("a"),
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/a")}, //lintignore:AWSAT003,AWSAT005
+		},
+		{
+			Id:          aws.String("b"),
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/b")}, //lintignore:AWSAT003,AWSAT005
+		},
+		{
+			Id:          aws.String("c"),
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/c")}, //lintignore:AWSAT003,AWSAT005
+		},
+	}
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error new session: %s", err)
+	}
+
+	conn := lakeformation.New(sess)
+
+	var revokeInputs []*lakeformation.BatchRevokePermissionsInput
+
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		switch params := r.Params.(type) {
+		case *lakeformation.BatchGrantPermissionsInput:
+			// The second entry fails, the others are granted.
+			r.Data.(*lakeformation.BatchGrantPermissionsOutput).Failures = []*lakeformation.BatchPermissionsFailureEntry{
+				{
+					Error: &lakeformation.ErrorDetail{
+						ErrorCode:    aws.String(lakeformation.ErrCodeInvalidInputException),
+						ErrorMessage: aws.String("Invalid principal"),
+					},
+					RequestEntry: params.Entries[1],
+				},
+			}
+		case *lakeformation.BatchRevokePermissionsInput:
+			revokeInputs = append(revokeInputs, params)
+		default:
+			t.Errorf("unexpected operation: %s", r.Operation.Name)
+		}
+	})
+
+	granted, err := lakeFormationBatchGrantPermissions(context.Background(), conn, "123456789012", entries, true)
+
+	if err == nil {
+		t.Fatal("expected an error for the failed entry")
+	}
+
+	if len(granted) != 0 {
+		t.Errorf("expected no entries to remain granted, got %v", granted)
+	}
+
+	if len(revokeInputs) != 1 {
+		t.Fatalf("expected 1 BatchRevokePermissions call, got %d", len(revokeInputs))
+	}
+
+	var got []string
+
+	for _, entry := range revokeInputs[0].Entries {
+		got = append(got, aws.StringValue(entry.Principal.DataLakePrincipalIdentifier))
+	}
+
+	expected := []string{
+		aws.StringValue(entries[0].Principal.DataLakePrincipalIdentifier),
+		aws.StringValue(entries[2].Principal.DataLakePrincipalIdentifier),
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestLakeFormationBatchPermissionsSucceededEntries(t *testing.T) {
+	entries := []*lakeformation.BatchPermissionsRequestEntry{
+		{Id: aws.String("0")},
+		{Id: aws.String("1")},
+		{Id: aws.String("2")},
+	}
+
+	testCases := []struct {
+		Name     string
+		Failures []*lakeformation.BatchPermissionsFailureEntry
+		Expected []string
+	}{
+		{
+			Name:     "no failures",
+			Expected: []string{"0", "1", "2"},
+		},
+		{
+			Name: "failure mid-batch",
+			Failures: []*lakeformation.BatchPermissionsFailureEntry{
+				{
+					RequestEntry: &lakeformation.BatchPermissionsRequestEntry{Id: aws.String("1")},
+					Error: &lakeformation.ErrorDetail{
+						ErrorCode:    aws.String(lakeformation.ErrCodeInvalidInputException),
+						ErrorMessage: aws.String("invalid principal"),
+					},
+				},
+			},
+			Expected: []string{"0", "2"},
+		},
+		{
+			Name: "all failed",
+			Failures: []*lakeformation.BatchPermissionsFailureEntry{
+				{RequestEntry: &lakeformation.BatchPermissionsRequestEntry{Id: aws.String("0")}},
+				{RequestEntry: &lakeformation.BatchPermissionsRequestEntry{Id: aws.String("1")}},
+				{RequestEntry: &lakeformation.BatchPermissionsRequestEntry{Id: aws.String("2")}},
+			},
+		},
+		{
+			Name: "failure without request entry",
+			Failures: []*lakeformation.BatchPermissionsFailureEntry{
+				{},
+			},
+			Expected: []string{"0", "1", "2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var got []string
+
+			for _, entry := range lakeFormationBatchPermissionsSucceededEntries(entries, testCase.Failures) {
+				got = append(got, aws.StringValue(entry.Id))
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestLakeFormationBatchPermissionsFailuresError(t *testing.T) {
+	if err := lakeFormationBatchPermissionsFailuresError(nil); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	err := lakeFormationBatchPermissionsFailuresError([]*lakeformation.BatchPermissionsFailureEntry{
+		{
+			RequestEntry: &lakeformation.BatchPermissionsRequestEntry{Id: aws.String("1")},
+			Error: &lakeformation.ErrorDetail{
+				ErrorCode:    aws.String(lakeformation.ErrCodeInvalidInputException),
+				ErrorMessage: aws.String("invalid principal"),
+			},
+		},
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestLakeFormationBatchPermissionsEntriesDifference(t *testing.T) {
+	tfMap := func(name, permission string) map[string]interface{} {
+		return map[string]interface{}{
+			"principal":   "123456789012",
+			"permissions": schema.NewSet(schema.HashString, []interface{}{permission}),
+			"database": []interface{}{
+				map[string]interface{}{"catalog_id": "", "name": name},
+			},
+		}
+	}
+
+	oldEntries := expandLakeFormationBatchPermissionsEntries([]interface{}{
+		tfMap("kept", lakeformation.PermissionDescribe),
+		tfMap("changed", lakeformation.PermissionDescribe),
+		tfMap("removed", lakeformation.PermissionDescribe),
+	}, "123456789012")
+	newEntries := expandLakeFormationBatchPermissionsEntries([]interface{}{
+		tfMap("added", lakeformation.PermissionDescribe),
+		tfMap("changed", lakeformation.PermissionAlter),
+		tfMap("kept", lakeformation.PermissionDescribe),
+	}, "123456789012")
+
+	testCases := []struct {
+		Name     string
+		A        []*lakeformation.BatchPermissionsRequestEntry
+		B        []*lakeformation.BatchPermissionsRequestEntry
+		Expected []string
+	}{
+		{
+			Name:     "revoke",
+			A:        oldEntries,
+			B:        newEntries,
+			Expected: []string{"123456789012,DATABASE,123456789012,changed", "123456789012,DATABASE,123456789012,removed"},
+		},
+		{
+			Name:     "grant",
+			A:        newEntries,
+			B:        oldEntries,
+			Expected: []string{"123456789012,DATABASE,123456789012,added", "123456789012,DATABASE,123456789012,changed"},
+		},
+		{
+			Name: "unchanged",
+			A:    oldEntries,
+			B:    oldEntries,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var got []string
+
+			for _, entry := range lakeFormationBatchPermissionsEntriesDifference(testCase.A, testCase.B) {
+				got = append(got, aws.StringValue(entry.Id))
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestLakeFormationBatchPermissionsResponseFailures(t *testing.T) {
+	entries := []*lakeformation.BatchPermissionsRequestEntry{
+		{Id: aws.String("123456789012,DATABASE,123456789012,first")},
+		{Id: aws.String("123456789012,DATABASE,123456789012,second")},
+	}
+
+	requestEntries := lakeFormationBatchPermissionsRequestEntries(entries)
+
+	if got, expected := aws.StringValue(requestEntries[1].Id), "1"; got != expected {
+		t.Errorf("got request entry ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := aws.StringValue(entries[1].Id), "123456789012,DATABASE,123456789012,second"; got != expected {
+		t.Errorf("got entry ID %s, expected %s", got, expected)
+	}
+
+	failures := lakeFormationBatchPermissionsResponseFailures(entries, []*lakeformation.BatchPermissionsFailureEntry{
+		{
+			RequestEntry: requestEntries[1],
+			Error: &lakeformation.ErrorDetail{
+				ErrorCode:    aws.String(lakeformation.ErrCodeEntityNotFoundException),
+				ErrorMessage: aws.String("Database not found"),
+			},
+		},
+	})
+
+	if got := lakeFormationBatchPermissionsSucceededEntries(entries, failures); !reflect.DeepEqual(got, entries[:1]) {
+		t.Errorf("got succeeded entries %v, expected %v", got, entries[:1])
+	}
+
+	if err := lakeFormationBatchPermissionsFailuresError(failures); err == nil || !strings.Contains(err.Error(), "entry (123456789012,DATABASE,123456789012,second): EntityNotFoundException: Database not found") {
+		t.Errorf("expected failure naming the entry, got %v", err)
+	}
+}
+
+func TestLakeFormationValidateBatchPermissionsEntry(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		TFMap       map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			Name: "catalog",
+			TFMap: map[string]interface{}{
+				"catalog_resource": true,
+			},
+		},
+		{
+			Name: "table",
+			TFMap: map[string]interface{}{
+				"catalog_resource": false,
+				"table":            []interface{}{map[string]interface{}{"database_name": "db", "name": "table"}},
+			},
+		},
+		{
+			Name: "none",
+			TFMap: map[string]interface{}{
+				"catalog_resource": false,
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "database and table",
+			TFMap: map[string]interface{}{
+				"catalog_resource": false,
+				"database":         []interface{}{map[string]interface{}{"name": "db"}},
+				"table":            []interface{}{map[string]interface{}{"database_name": "db", "name": "table"}},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := lakeFormationValidateBatchPermissionsEntry(testCase.TFMap)
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLakeFormationBatchPermissionsDuplicateEntriesError(t *testing.T) {
+	entries := []*lakeformation.BatchPermissionsRequestEntry{
+		{Id: aws.String("123456789012,DATABASE,123456789012,db")},
+		{Id: aws.String("123456789012,DATABASE,123456789012,other")},
+	}
+
+	if err := lakeFormationBatchPermissionsDuplicateEntriesError(entries); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	if err := lakeFormationBatchPermissionsDuplicateEntriesError(append(entries, entries[0])); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func testAccAWSLakeFormationBatchPermissions_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_batch_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationBatchPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationBatchPermissionsConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationBatchPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "rollback_on_failure", "true"),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "entry.0.database.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "entry.0.principal", "aws_iam_role.test", "arn"),
+					resource.TestCheckResourceAttr(resourceName, "entry.1.table.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "entry.1.principal", "aws_iam_role.test", "arn"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationBatchPermissions_update(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_batch_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationBatchPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationBatchPermissionsConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationBatchPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+				),
+			},
+			{
+				Config: testAccAWSLakeFormationBatchPermissionsConfig_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationBatchPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "entry.0.table.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "entry.0.permissions.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "entry.0.permissions.*", lakeformation.PermissionDescribe),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationBatchPermissions_multipleResources(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationBatchPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSLakeFormationBatchPermissionsConfig_multipleResources(rName),
+				ExpectError: regexp.MustCompile(`entry 0: exactly one of`),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLakeFormationBatchPermissionsDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_lakeformation_batch_permissions" {
+			continue
+		}
+
+		principal := rs.Primary.Attributes["entry.0.principal"]
+
+		input := &lakeformation.ListPermissionsInput{
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String(principal),
+			},
+		}
+
+		_, err := conn.ListPermissions(input)
+		if err == nil {
+			return fmt.Errorf("Resource still registered: %s", principal)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLakeFormationBatchPermissionsExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+		input := &lakeformation.ListPermissionsInput{
+			MaxResults: aws.Int64(1),
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String(rs.Primary.Attributes["entry.0.principal"]),
+			},
+		}
+
+		output, err := conn.ListPermissions(input)
+
+		if err != nil {
+			return err
+		}
+
+		if output == nil || len(output.PrincipalResourcePermissions) == 0 {
+			return fmt.Errorf("no Lake Formation permissions found for %s", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSLakeFormationBatchPermissionsConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_batch_permissions" "test" {
+  entry {
+    permissions = ["CREATE_TABLE"]
+    principal   = aws_iam_role.test.arn
+
+    database {
+      name = aws_glue_catalog_database.test.name
+    }
+  }
+
+  entry {
+    permissions = ["ALTER", "DESCRIBE"]
+    principal   = aws_iam_role.test.arn
+
+    table {
+      database_name = aws_glue_catalog_table.test.database_name
+      name          = aws_glue_catalog_table.test.name
+    }
+  }
+
+  depends_on = ["aws_lakeformation_data_lake_settings.test"]
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationBatchPermissionsConfig_update(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_batch_permissions" "test" {
+  entry {
+    permissions = ["DESCRIBE"]
+    principal   = aws_iam_role.test.arn
+
+    table {
+      database_name = aws_glue_catalog_table.test.database_name
+      name          = aws_glue_catalog_table.test.name
+    }
+  }
+
+  depends_on = ["aws_lakeformation_data_lake_settings.test"]
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationBatchPermissionsConfig_multipleResources(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_batch_permissions" "test" {
+  entry {
+    permissions = ["DESCRIBE"]
+    principal   = aws_iam_role.test.arn
+
+    database {
+      name = aws_glue_catalog_database.test.name
+    }
+
+    table {
+      database_name = aws_glue_catalog_table.test.database_name
+      name          = aws_glue_catalog_table.test.name
+    }
+  }
+
+  depends_on = ["aws_lakeformation_data_lake_settings.test"]
+}
+`, rName)
+}
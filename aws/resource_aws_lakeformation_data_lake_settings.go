@@ -35,9 +35,10 @@ func resourceAwsLakeFormationDataLakeSettings() *schema.Resource {
 				},
 			},
 			"catalog_id": {
-				Type:     schema.TypeString,
-				ForceNew: true,
-				Optional: true,
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validateAwsAccountId,
 			},
 			"create_database_default_permissions": {
 				Type:     schema.TypeList,
@@ -89,6 +90,11 @@ func resourceAwsLakeFormationDataLakeSettings() *schema.Resource {
 					},
 				},
 			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"trusted_resource_owners": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -113,8 +119,17 @@ func resourceAwsLakeFormationDataLakeSettingsCreate(d *schema.ResourceData, meta
 
 	settings := &lakeformation.DataLakeSettings{}
 
-	if v, ok := d.GetOk("create_database_default_permissions"); ok {
-		settings.CreateDatabaseDefaultPermissions = expandDataLakeSettingsCreateDefaultPermissions(v.([]interface{}))
+	if d.HasChange("create_database_default_permissions") {
+		settings.CreateDatabaseDefaultPermissions = expandDataLakeSettingsCreateDefaultPermissions(d.Get("create_database_default_permissions").([]interface{}))
+	} else {
+		// Keep the current entries, e.g. those managed by aws_lakeformation_database_default_permissions.
+		v, err := resourceAwsLakeFormationDataLakeSettingsCurrentCreateDatabaseDefaultPermissions(conn, input.CatalogId)
+
+		if err != nil {
+			return fmt.Errorf("error creating Lake Formation data lake settings: %w", err)
+		}
+
+		settings.CreateDatabaseDefaultPermissions = v
 	}
 
 	if v, ok := d.GetOk("create_table_default_permissions"); ok {
@@ -142,6 +157,9 @@ func resourceAwsLakeFormationDataLakeSettingsCreate(d *schema.ResourceData, meta
 			if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") {
 				return resource.RetryableError(err)
 			}
+			if isLakeFormationThrottlingError(err) {
+				return resource.RetryableError(err)
+			}
 
 			return resource.NonRetryableError(fmt.Errorf("error creating Lake Formation data lake settings: %w", err))
 		}
@@ -165,6 +183,28 @@ func resourceAwsLakeFormationDataLakeSettingsCreate(d *schema.ResourceData, meta
 	return resourceAwsLakeFormationDataLakeSettingsRead(d, meta)
 }
 
+// resourceAwsLakeFormationDataLakeSettingsCurrentCreateDatabaseDefaultPermissions returns the default create database
+// permissions currently in the data lake settings.
+func resourceAwsLakeFormationDataLakeSettingsCurrentCreateDatabaseDefaultPermissions(conn *lakeformation.LakeFormation, catalogID *string) ([]*lakeformation.PrincipalPermissions, error) {
+	output, err := conn.GetDataLakeSettings(&lakeformation.GetDataLakeSettingsInput{
+		CatalogId: catalogID,
+	})
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading default permissions: %w", err)
+	}
+
+	if output == nil || output.DataLakeSettings == nil {
+		return nil, nil
+	}
+
+	return output.DataLakeSettings.CreateDatabaseDefaultPermissions, nil
+}
+
 func resourceAwsLakeFormationDataLakeSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).lakeformationconn
 
@@ -203,6 +243,12 @@ func resourceAwsLakeFormationDataLakeSettingsRead(d *schema.ResourceData, meta i
 func resourceAwsLakeFormationDataLakeSettingsDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).lakeformationconn
 
+	if d.Get("force_destroy").(bool) {
+		if err := resourceAwsLakeFormationDataLakeSettingsRevokeDefaultPermissions(d, conn); err != nil {
+			return fmt.Errorf("deleting Lake Formation data lake settings (%s): %w", d.Id(), err)
+		}
+	}
+
 	input := &lakeformation.PutDataLakeSettingsInput{
 		DataLakeSettings: &lakeformation.DataLakeSettings{
 			CreateDatabaseDefaultPermissions: make([]*lakeformation.PrincipalPermissions, 0),
@@ -230,6 +276,103 @@ func resourceAwsLakeFormationDataLakeSettingsDelete(d *schema.ResourceData, meta
 	return nil
 }
 
+// resourceAwsLakeFormationDataLakeSettingsRevokeDefaultPermissions removes the default create database and create table
+// permissions managed by this resource while leaving the administrators in place, so that clearing the remaining
+// settings does not fail on dependent grants.
+func resourceAwsLakeFormationDataLakeSettingsRevokeDefaultPermissions(d *schema.ResourceData, conn *lakeformation.LakeFormation) error {
+	getInput := &lakeformation.GetDataLakeSettingsInput{}
+
+	if v, ok := d.GetOk("catalog_id"); ok {
+		getInput.CatalogId = aws.String(v.(string))
+	}
+
+	output, err := conn.GetDataLakeSettings(getInput)
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading default permissions: %w", err)
+	}
+
+	if output == nil || output.DataLakeSettings == nil {
+		return nil
+	}
+
+	putInput := &lakeformation.PutDataLakeSettingsInput{
+		CatalogId:        getInput.CatalogId,
+		DataLakeSettings: removeDataLakeSettingsManaged(output.DataLakeSettings, expandDataLakeSettingsManaged(d)),
+	}
+
+	log.Printf("[DEBUG] Revoking Lake Formation default permissions: %s", putInput)
+	err = resource.Retry(iamwaiter.PropagationTimeout, func() *resource.RetryError {
+		_, err := conn.PutDataLakeSettings(putInput)
+		if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") || isLakeFormationThrottlingError(err) {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.PutDataLakeSettings(putInput)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error revoking default permissions: %w", err)
+	}
+
+	return nil
+}
+
+// expandDataLakeSettingsManaged returns the default create database and create table permissions in state.
+func expandDataLakeSettingsManaged(d *schema.ResourceData) *lakeformation.DataLakeSettings {
+	return &lakeformation.DataLakeSettings{
+		CreateDatabaseDefaultPermissions: expandDataLakeSettingsCreateDefaultPermissions(d.Get("create_database_default_permissions").([]interface{})),
+		CreateTableDefaultPermissions:    expandDataLakeSettingsCreateDefaultPermissions(d.Get("create_table_default_permissions").([]interface{})),
+	}
+}
+
+// removeDataLakeSettingsManaged returns a copy of the settings without the managed default permissions. Admins and
+// trusted resource owners are kept.
+func removeDataLakeSettingsManaged(settings, managed *lakeformation.DataLakeSettings) *lakeformation.DataLakeSettings {
+	v := *settings
+	v.CreateDatabaseDefaultPermissions = removeDataLakeSettingsCreateDefaultPermissions(settings.CreateDatabaseDefaultPermissions, managed.CreateDatabaseDefaultPermissions)
+	v.CreateTableDefaultPermissions = removeDataLakeSettingsCreateDefaultPermissions(settings.CreateTableDefaultPermissions, managed.CreateTableDefaultPermissions)
+
+	return &v
+}
+
+// removeDataLakeSettingsCreateDefaultPermissions returns the default permissions that are not granted to a managed principal.
+func removeDataLakeSettingsCreateDefaultPermissions(apiObjects, managed []*lakeformation.PrincipalPermissions) []*lakeformation.PrincipalPermissions {
+	principals := make(map[string]bool, len(managed))
+
+	for _, apiObject := range managed {
+		if apiObject == nil || apiObject.Principal == nil {
+			continue
+		}
+
+		principals[aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier)] = true
+	}
+
+	remaining := make([]*lakeformation.PrincipalPermissions, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Principal == nil {
+			continue
+		}
+
+		if !principals[aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier)] {
+			remaining = append(remaining, apiObject)
+		}
+	}
+
+	return remaining
+}
+
 func expandDataLakeSettingsCreateDefaultPermissions(tfMaps []interface{}) []*lakeformation.PrincipalPermissions {
 	apiObjects := make([]*lakeformation.PrincipalPermissions, 0, len(tfMaps))
 
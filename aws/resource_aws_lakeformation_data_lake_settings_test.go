@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -34,6 +35,32 @@ func testAccAWSLakeFormationDataLakeSettings_basic(t *testing.T) {
 	})
 }
 
+func testAccAWSLakeFormationDataLakeSettings_forceDestroy(t *testing.T) {
+	resourceName := "aws_lakeformation_data_lake_settings.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationDataLakeSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationDataLakeSettingsConfig_forceDestroy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationDataLakeSettingsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "create_database_default_permissions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "create_table_default_permissions.#", "1"),
+				),
+			},
+			{
+				Config: testAccAWSLakeFormationDataLakeSettingsConfig_forceDestroyRemoved,
+				Check:  testAccCheckAWSLakeFormationDataLakeSettingsDefaultPermissionsRevoked,
+			},
+		},
+	})
+}
+
 func testAccAWSLakeFormationDataLakeSettings_disappears(t *testing.T) {
 	resourceName := "aws_lakeformation_data_lake_settings.test"
 
@@ -77,6 +104,80 @@ func testAccAWSLakeFormationDataLakeSettings_withoutCatalogId(t *testing.T) {
 	})
 }
 
+func TestRemoveDataLakeSettingsManaged(t *testing.T) {
+	admin := &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/admin")}      //lintignore:AWSAT003,AWSAT005
+	otherAdmin := &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/other")} //lintignore:AWSAT003,AWSAT005
+	analyst := &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/analyst")}  //lintignore:AWSAT003,AWSAT005
+	iamAllowed := &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("IAM_ALLOWED_PRINCIPALS")}
+
+	settings := &lakeformation.DataLakeSettings{
+		CreateDatabaseDefaultPermissions: []*lakeformation.PrincipalPermissions{
+			{Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}), Principal: iamAllowed},
+		},
+		CreateTableDefaultPermissions: []*lakeformation.PrincipalPermissions{
+			{Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}), Principal: iamAllowed},
+			{Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}), Principal: analyst},
+		},
+		DataLakeAdmins:        []*lakeformation.DataLakePrincipal{admin, otherAdmin},
+		TrustedResourceOwners: aws.StringSlice([]string{"123456789012", "210987654321"}),
+	}
+
+	// Only the database defaults for IAM_ALLOWED_PRINCIPALS are configured.
+	managed := &lakeformation.DataLakeSettings{
+		CreateDatabaseDefaultPermissions: []*lakeformation.PrincipalPermissions{
+			{Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}), Principal: iamAllowed},
+		},
+		CreateTableDefaultPermissions: []*lakeformation.PrincipalPermissions{},
+	}
+
+	got := removeDataLakeSettingsManaged(settings, managed)
+
+	expected := &lakeformation.DataLakeSettings{
+		CreateDatabaseDefaultPermissions: []*lakeformation.PrincipalPermissions{},
+		CreateTableDefaultPermissions:    settings.CreateTableDefaultPermissions,
+		DataLakeAdmins:                   []*lakeformation.DataLakePrincipal{admin, otherAdmin},
+		TrustedResourceOwners:            aws.StringSlice([]string{"123456789012", "210987654321"}),
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if len(settings.CreateDatabaseDefaultPermissions) != 1 {
+		t.Errorf("expected the current settings not to be modified, got %v", settings)
+	}
+}
+
+func TestRemoveDataLakeSettingsCreateDefaultPermissions(t *testing.T) {
+	current := []*lakeformation.PrincipalPermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("IAM_ALLOWED_PRINCIPALS")},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/other")}, //lintignore:AWSAT003,AWSAT005
+		},
+	}
+	managed := []*lakeformation.PrincipalPermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("IAM_ALLOWED_PRINCIPALS")},
+		},
+	}
+
+	got := removeDataLakeSettingsCreateDefaultPermissions(current, managed)
+	expected := []*lakeformation.PrincipalPermissions{current[1]}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := removeDataLakeSettingsCreateDefaultPermissions(current, nil); len(got) != len(current) {
+		t.Errorf("expected unmanaged default permissions to be kept, got %v", got)
+	}
+}
+
 func testAccCheckAWSLakeFormationDataLakeSettingsDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
 
@@ -109,6 +210,26 @@ func testAccCheckAWSLakeFormationDataLakeSettingsDestroy(s *terraform.State) err
 	return nil
 }
 
+// testAccCheckAWSLakeFormationDataLakeSettingsDefaultPermissionsRevoked checks that destroying the settings removed the
+// default create database and create table permissions.
+func testAccCheckAWSLakeFormationDataLakeSettingsDefaultPermissionsRevoked(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+	output, err := conn.GetDataLakeSettings(&lakeformation.GetDataLakeSettingsInput{})
+
+	if err != nil {
+		return fmt.Errorf("error getting Lake Formation data lake settings: %w", err)
+	}
+
+	settings := output.DataLakeSettings
+
+	if len(settings.CreateDatabaseDefaultPermissions) != 0 || len(settings.CreateTableDefaultPermissions) != 0 {
+		return fmt.Errorf("expected the default permissions to be revoked, got %v", settings)
+	}
+
+	return nil
+}
+
 func testAccCheckAWSLakeFormationDataLakeSettingsExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -162,3 +283,26 @@ resource "aws_lakeformation_data_lake_settings" "test" {
   admins = [data.aws_caller_identity.current.arn]
 }
 `
+
+const testAccAWSLakeFormationDataLakeSettingsConfig_forceDestroy = `
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins        = [data.aws_caller_identity.current.arn]
+  force_destroy = true
+
+  create_database_default_permissions {
+    principal   = "IAM_ALLOWED_PRINCIPALS"
+    permissions = ["ALL"]
+  }
+
+  create_table_default_permissions {
+    principal   = "IAM_ALLOWED_PRINCIPALS"
+    permissions = ["ALL"]
+  }
+}
+`
+
+const testAccAWSLakeFormationDataLakeSettingsConfig_forceDestroyRemoved = `
+data "aws_caller_identity" "current" {}
+`
@@ -0,0 +1,272 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	iamwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/iam/waiter"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+)
+
+func resourceAwsLakeFormationDatabaseDefaultPermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsLakeFormationDatabaseDefaultPermissionsCreate,
+		Read:   resourceAwsLakeFormationDatabaseDefaultPermissionsRead,
+		Update: resourceAwsLakeFormationDatabaseDefaultPermissionsCreate,
+		Delete: resourceAwsLakeFormationDatabaseDefaultPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAwsLakeFormationDatabaseDefaultPermissionsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(lakeformation.Permission_Values(), false),
+				},
+			},
+			"principal": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePrincipal,
+			},
+		},
+	}
+}
+
+func resourceAwsLakeFormationDatabaseDefaultPermissionsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).lakeformationconn
+
+	catalogID := meta.(*AWSClient).accountid
+	if v, ok := d.GetOk("catalog_id"); ok {
+		catalogID = v.(string)
+	}
+
+	entry := &lakeformation.PrincipalPermissions{
+		Permissions: expandStringSet(d.Get("permissions").(*schema.Set)),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+		},
+	}
+
+	err := resourceAwsLakeFormationDatabaseDefaultPermissionsUpdateSettings(conn, catalogID, func(apiObjects []*lakeformation.PrincipalPermissions) []*lakeformation.PrincipalPermissions {
+		return upsertDataLakeSettingsCreateDefaultPermissions(apiObjects, entry)
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating Lake Formation database default permissions: %w", err)
+	}
+
+	if d.IsNewResource() {
+		d.SetId(tflakeformation.DatabaseDefaultPermissionsCreateID(catalogID, d.Get("principal").(string)))
+	}
+
+	return resourceAwsLakeFormationDatabaseDefaultPermissionsRead(d, meta)
+}
+
+func resourceAwsLakeFormationDatabaseDefaultPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).lakeformationconn
+
+	catalogID := meta.(*AWSClient).accountid
+	if v, ok := d.GetOk("catalog_id"); ok {
+		catalogID = v.(string)
+	}
+
+	input := &lakeformation.GetDataLakeSettingsInput{
+		CatalogId: aws.String(catalogID),
+	}
+
+	output, err := conn.GetDataLakeSettings(input)
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		log.Printf("[WARN] Lake Formation database default permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading Lake Formation database default permissions (%s): %w", d.Id(), err)
+	}
+
+	if output == nil || output.DataLakeSettings == nil {
+		return fmt.Errorf("reading Lake Formation database default permissions (%s): empty response", d.Id())
+	}
+
+	entry := findDataLakeSettingsCreateDefaultPermission(output.DataLakeSettings.CreateDatabaseDefaultPermissions, d.Get("principal").(string))
+
+	if !d.IsNewResource() && entry == nil {
+		log.Printf("[WARN] Lake Formation database default permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if entry == nil {
+		return fmt.Errorf("reading Lake Formation database default permissions (%s): not found", d.Id())
+	}
+
+	d.Set("catalog_id", catalogID)
+	d.Set("permissions", flattenStringSet(entry.Permissions))
+	d.Set("principal", entry.Principal.DataLakePrincipalIdentifier)
+
+	return nil
+}
+
+func resourceAwsLakeFormationDatabaseDefaultPermissionsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	catalogID, principal, err := tflakeformation.DatabaseDefaultPermissionsParseID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("catalog_id", catalogID)
+	d.Set("principal", principal)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAwsLakeFormationDatabaseDefaultPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).lakeformationconn
+
+	managed := []*lakeformation.PrincipalPermissions{
+		{
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+			},
+		},
+	}
+
+	err := resourceAwsLakeFormationDatabaseDefaultPermissionsUpdateSettings(conn, d.Get("catalog_id").(string), func(apiObjects []*lakeformation.PrincipalPermissions) []*lakeformation.PrincipalPermissions {
+		return removeDataLakeSettingsCreateDefaultPermissions(apiObjects, managed)
+	})
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("deleting Lake Formation database default permissions (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsLakeFormationDatabaseDefaultPermissionsUpdateSettings reads the current data lake settings, applies
+// update to the default create database permissions and writes the settings back, leaving everything else as is.
+func resourceAwsLakeFormationDatabaseDefaultPermissionsUpdateSettings(conn *lakeformation.LakeFormation, catalogID string, update func([]*lakeformation.PrincipalPermissions) []*lakeformation.PrincipalPermissions) error {
+	getInput := &lakeformation.GetDataLakeSettingsInput{}
+
+	if catalogID != "" {
+		getInput.CatalogId = aws.String(catalogID)
+	}
+
+	err := resource.Retry(iamwaiter.PropagationTimeout, func() *resource.RetryError {
+		output, err := conn.GetDataLakeSettings(getInput)
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if output == nil || output.DataLakeSettings == nil {
+			return resource.NonRetryableError(fmt.Errorf("empty response"))
+		}
+
+		settings := output.DataLakeSettings
+		settings.CreateDatabaseDefaultPermissions = update(settings.CreateDatabaseDefaultPermissions)
+
+		_, err = conn.PutDataLakeSettings(&lakeformation.PutDataLakeSettingsInput{
+			CatalogId:        getInput.CatalogId,
+			DataLakeSettings: settings,
+		})
+
+		if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
+			return resource.RetryableError(err)
+		}
+		if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") || isLakeFormationThrottlingError(err) {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		var output *lakeformation.GetDataLakeSettingsOutput
+		output, err = conn.GetDataLakeSettings(getInput)
+
+		if err == nil && output != nil && output.DataLakeSettings != nil {
+			settings := output.DataLakeSettings
+			settings.CreateDatabaseDefaultPermissions = update(settings.CreateDatabaseDefaultPermissions)
+
+			_, err = conn.PutDataLakeSettings(&lakeformation.PutDataLakeSettingsInput{
+				CatalogId:        getInput.CatalogId,
+				DataLakeSettings: settings,
+			})
+		}
+	}
+
+	return err
+}
+
+// findDataLakeSettingsCreateDefaultPermission returns the default permissions granted to principal, if any.
+func findDataLakeSettingsCreateDefaultPermission(apiObjects []*lakeformation.PrincipalPermissions, principal string) *lakeformation.PrincipalPermissions {
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Principal == nil {
+			continue
+		}
+
+		if aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier) == principal {
+			return apiObject
+		}
+	}
+
+	return nil
+}
+
+// upsertDataLakeSettingsCreateDefaultPermissions replaces the default permissions for the entry's principal,
+// or appends the entry when the principal has none.
+func upsertDataLakeSettingsCreateDefaultPermissions(apiObjects []*lakeformation.PrincipalPermissions, entry *lakeformation.PrincipalPermissions) []*lakeformation.PrincipalPermissions {
+	principal := aws.StringValue(entry.Principal.DataLakePrincipalIdentifier)
+	result := make([]*lakeformation.PrincipalPermissions, 0, len(apiObjects)+1)
+	var found bool
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil {
+			continue
+		}
+
+		if apiObject.Principal != nil && aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier) == principal {
+			if !found {
+				result = append(result, entry)
+				found = true
+			}
+			continue
+		}
+
+		result = append(result, apiObject)
+	}
+
+	if !found {
+		result = append(result, entry)
+	}
+
+	return result
+}
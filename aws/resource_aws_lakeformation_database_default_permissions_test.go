@@ -0,0 +1,280 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestUpsertDataLakeSettingsCreateDefaultPermissions(t *testing.T) {
+	iamAllowed := &lakeformation.PrincipalPermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+		Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("IAM_ALLOWED_PRINCIPALS")},
+	}
+	roleOld := &lakeformation.PrincipalPermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+		Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test")}, //lintignore:AWSAT003,AWSAT005
+	}
+	roleNew := &lakeformation.PrincipalPermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionDescribe}),
+		Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test")}, //lintignore:AWSAT003,AWSAT005
+	}
+
+	testCases := []struct {
+		Name     string
+		Current  []*lakeformation.PrincipalPermissions
+		Entry    *lakeformation.PrincipalPermissions
+		Expected []*lakeformation.PrincipalPermissions
+	}{
+		{
+			Name:     "empty",
+			Entry:    roleNew,
+			Expected: []*lakeformation.PrincipalPermissions{roleNew},
+		},
+		{
+			Name:     "append",
+			Current:  []*lakeformation.PrincipalPermissions{iamAllowed},
+			Entry:    roleNew,
+			Expected: []*lakeformation.PrincipalPermissions{iamAllowed, roleNew},
+		},
+		{
+			Name:     "replace",
+			Current:  []*lakeformation.PrincipalPermissions{roleOld, iamAllowed},
+			Entry:    roleNew,
+			Expected: []*lakeformation.PrincipalPermissions{roleNew, iamAllowed},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := upsertDataLakeSettingsCreateDefaultPermissions(testCase.Current, testCase.Entry)
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestFindDataLakeSettingsCreateDefaultPermission(t *testing.T) {
+	apiObjects := []*lakeformation.PrincipalPermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Principal:   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("IAM_ALLOWED_PRINCIPALS")},
+		},
+	}
+
+	if got := findDataLakeSettingsCreateDefaultPermission(apiObjects, "IAM_ALLOWED_PRINCIPALS"); got != apiObjects[0] {
+		t.Errorf("expected %v, got %v", apiObjects[0], got)
+	}
+
+	if got := findDataLakeSettingsCreateDefaultPermission(apiObjects, "123456789012"); got != nil {
+		t.Errorf("expected no entry, got %v", got)
+	}
+}
+
+func testAccAWSLakeFormationDatabaseDefaultPermissions_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_database_default_permissions.test"
+	roleName := "aws_iam_role.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationDatabaseDefaultPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationDatabaseDefaultPermissionsConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationDatabaseDefaultPermissionsExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionAlter),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionDescribe),
+					testAccCheckResourceAttrAccountID(resourceName, "catalog_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationDatabaseDefaultPermissions_dataLakeSettingsUpdate(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_database_default_permissions.test"
+	settingsResourceName := "aws_lakeformation_data_lake_settings.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationDatabaseDefaultPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationDatabaseDefaultPermissionsConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationDatabaseDefaultPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(settingsResourceName, "trusted_resource_owners.#", "0"),
+				),
+			},
+			{
+				// Updating the data lake settings must keep the default permissions managed by the other resource.
+				Config: testAccAWSLakeFormationDatabaseDefaultPermissionsConfig_dataLakeSettingsUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationDatabaseDefaultPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(settingsResourceName, "trusted_resource_owners.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLakeFormationDatabaseDefaultPermissionsDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_lakeformation_database_default_permissions" {
+			continue
+		}
+
+		input := &lakeformation.GetDataLakeSettingsInput{}
+
+		if rs.Primary.Attributes["catalog_id"] != "" {
+			input.CatalogId = aws.String(rs.Primary.Attributes["catalog_id"])
+		}
+
+		output, err := conn.GetDataLakeSettings(input)
+
+		if err != nil {
+			return err
+		}
+
+		if output != nil && output.DataLakeSettings != nil && findDataLakeSettingsCreateDefaultPermission(output.DataLakeSettings.CreateDatabaseDefaultPermissions, rs.Primary.Attributes["principal"]) != nil {
+			return fmt.Errorf("Lake Formation database default permissions (%s) still exist", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSLakeFormationDatabaseDefaultPermissionsExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+		input := &lakeformation.GetDataLakeSettingsInput{}
+
+		if rs.Primary.Attributes["catalog_id"] != "" {
+			input.CatalogId = aws.String(rs.Primary.Attributes["catalog_id"])
+		}
+
+		output, err := conn.GetDataLakeSettings(input)
+
+		if err != nil {
+			return err
+		}
+
+		if output == nil || output.DataLakeSettings == nil || findDataLakeSettingsCreateDefaultPermission(output.DataLakeSettings.CreateDatabaseDefaultPermissions, rs.Primary.Attributes["principal"]) == nil {
+			return fmt.Errorf("Lake Formation database default permissions (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSLakeFormationDatabaseDefaultPermissionsConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_database_default_permissions" "test" {
+  permissions = ["ALTER", "DESCRIBE"]
+  principal   = aws_iam_role.test.arn
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationDatabaseDefaultPermissionsConfig_dataLakeSettingsUpdate(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins                  = [data.aws_caller_identity.current.arn]
+  trusted_resource_owners = [data.aws_caller_identity.current.account_id]
+}
+
+resource "aws_lakeformation_database_default_permissions" "test" {
+  permissions = ["ALTER", "DESCRIBE"]
+  principal   = aws_iam_role.test.arn
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName)
+}
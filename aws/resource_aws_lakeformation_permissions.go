@@ -1,85 +1,164 @@
 package aws
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"reflect"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/aws/aws-sdk-go/service/ram"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/terraform-providers/terraform-provider-aws/aws/internal/hashcode"
+	gluefinder "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/glue/finder"
 	iamwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/iam/waiter"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
+	lakeformationwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation/waiter"
+	ramfinder "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/ram/finder"
 )
 
 func resourceAwsLakeFormationPermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAwsLakeFormationPermissionsCreate,
-		Read:   resourceAwsLakeFormationPermissionsRead,
-		Update: resourceAwsLakeFormationPermissionsCreate,
-		Delete: resourceAwsLakeFormationPermissionsDelete,
+		CreateContext: resourceAwsLakeFormationPermissionsCreate,
+		ReadContext:   resourceAwsLakeFormationPermissionsRead,
+		UpdateContext: resourceAwsLakeFormationPermissionsCreate,
+		DeleteContext: resourceAwsLakeFormationPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAwsLakeFormationPermissionsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(iamwaiter.PropagationTimeout),
+			Read:   schema.DefaultTimeout(iamwaiter.PropagationTimeout),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsLakeFormationPermissionsValidateResource,
+			resourceAwsLakeFormationPermissionsCatalogIdDiff,
+			resourceAwsLakeFormationPermissionsValidateCatalog,
+			resourceAwsLakeFormationPermissionsValidateColumnCount,
+			resourceAwsLakeFormationPermissionsLogChanges,
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceAwsLakeFormationPermissionsResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceAwsLakeFormationPermissionsStateUpgradeV0,
+				Version: 0,
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			"catalog_id": {
 				Type:         schema.TypeString,
 				ForceNew:     true,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validateAwsAccountId,
 			},
+			"allow_catalog_revoke": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"catalog_resource": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"check_principal_exists": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"cross_account_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"data_location": {
 				Type:          schema.TypeList,
 				Optional:      true,
 				Computed:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"database", "table", "table_with_columns"},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"arn": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validateArn,
-						},
-						"catalog_id": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validateAwsAccountId,
-						},
-					},
-				},
+				ConflictsWith: []string{"database", "databases", "table", "table_with_columns"},
+				Elem:          lakeFormationDataLocationResourceElem(),
 			},
 			"database": {
 				Type:          schema.TypeList,
 				Optional:      true,
 				Computed:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"data_location", "table", "table_with_columns"},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"catalog_id": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validateAwsAccountId,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
+				ConflictsWith: []string{"data_location", "databases", "table", "table_with_columns"},
+				Elem:          lakeFormationDatabaseResourceElem(),
+			},
+			"databases": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				MinItems:      1,
+				ConflictsWith: []string{"data_location", "database", "table", "table_with_columns"},
+				Elem:          lakeFormationDatabaseResourceElem(),
+			},
+			"effective_principal": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"feasibility_issues": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
 				},
 			},
-			"permissions": {
+			"has_companion_select": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ignore_column_name_case": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ignore_table_name_case": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"multiple_matches": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      lakeFormationMultipleMatchesError,
+				ValidateFunc: validation.StringInSlice(lakeFormationMultipleMatches_Values(), false),
+			},
+			"normalized_permissions": {
 				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
 				Required: true,
 				ForceNew: true,
 				MinItems: 1,
@@ -88,8 +167,30 @@ func resourceAwsLakeFormationPermissions() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(lakeformation.Permission_Values(), false),
 				},
 			},
-			"permissions_with_grant_option": {
+			"permissions_diff": {
 				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"extra_in_aws": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"missing_in_aws": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"permissions_with_grant_option": {
+				Type:     schema.TypeSet,
 				Optional: true,
 				ForceNew: true,
 				Computed: true,
@@ -98,337 +199,2482 @@ func resourceAwsLakeFormationPermissions() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(lakeformation.Permission_Values(), false),
 				},
 			},
+			"poll_interval": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					duration, err := time.ParseDuration(value)
+					if err != nil {
+						errors = append(errors, fmt.Errorf(
+							"%q cannot be parsed as a duration: %s", k, err))
+					}
+					if duration < 1*time.Second || duration > 60*time.Second {
+						errors = append(errors, fmt.Errorf(
+							"%q must be between 1s and 60s", k))
+					}
+					return
+				},
+			},
 			"principal": {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
 				ValidateFunc: validatePrincipal,
 			},
+			"register_data_location": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"register_data_location_role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateArn,
+			},
+			"revoke_all_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"skip_select_companion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"table": {
 				Type:          schema.TypeList,
 				Optional:      true,
 				Computed:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"data_location", "database", "table_with_columns"},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"catalog_id": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validateAwsAccountId,
-						},
-						"database_name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"wildcard": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-					},
-				},
+				ConflictsWith: []string{"data_location", "database", "databases", "table_with_columns"},
+				Elem:          lakeFormationTableResourceElem(),
 			},
 			"table_with_columns": {
 				Type:          schema.TypeList,
 				Optional:      true,
 				Computed:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"data_location", "database", "table"},
+				ConflictsWith: []string{"data_location", "database", "databases", "table"},
+				Elem:          lakeFormationTableWithColumnsResourceElem(),
+			},
+			"target_database": {
+				Type:     schema.TypeList,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"catalog_id": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							Computed:     true,
-							ValidateFunc: validateAwsAccountId,
-						},
-						"column_names": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type:         schema.TypeString,
-								ValidateFunc: validation.NoZeroValues,
-							},
-						},
-						"database_name": {
 							Type:     schema.TypeString,
-							Required: true,
-						},
-						"excluded_column_names": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type:         schema.TypeString,
-								ValidateFunc: validation.NoZeroValues,
-							},
+							Computed: true,
 						},
 						"name": {
 							Type:     schema.TypeString,
-							Required: true,
+							Computed: true,
 						},
 					},
 				},
 			},
+			"validate_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func lakeFormationDataLocationResourceElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"catalog_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+		},
+	}
+}
+
+func lakeFormationDatabaseResourceElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func lakeFormationTableResourceElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"database_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"wildcard": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func lakeFormationTableWithColumnsResourceElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"catalog_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"column_names": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"database_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"excluded_column_names": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"wildcard": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAwsLakeFormationPermissionsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	var diags diag.Diagnostics
+
+	if err := lakeFormationValidateResourceBlocks(d.Get("catalog_resource").(bool), d.Get("data_location").([]interface{}), d.Get("database").([]interface{}), d.Get("databases").(*schema.Set).List(), d.Get("table").([]interface{}), d.Get("table_with_columns").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &lakeformation.GrantPermissionsInput{
+		Permissions: expandStringSet(d.Get("permissions").(*schema.Set)),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
 		},
 	}
+
+	if v, ok := d.GetOk("catalog_id"); ok {
+		input.CatalogId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("permissions_with_grant_option"); ok {
+		input.PermissionsWithGrantOption = expandStringSet(v.(*schema.Set))
+	}
+
+	if d.IsNewResource() && d.Get("check_principal_exists").(bool) {
+		if warning := lakeFormationIamPrincipalWarning(d.Get("principal").(string), lakeFormationIamPrincipalLookup(meta.(*AWSClient).iamconn)); warning != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  warning,
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("databases"); ok && v.(*schema.Set).Len() > 0 {
+		return append(diags, resourceAwsLakeFormationPermissionsCreateDatabases(ctx, d, meta, input)...)
+	}
+
+	input.Resource = expandLakeFormationResource(d, false)
+
+	catalogId := meta.(*AWSClient).accountid
+	if input.CatalogId != nil {
+		catalogId = aws.StringValue(input.CatalogId)
+	}
+
+	apiObject := tflakeformation.ResourceWithEffectiveCatalogID(*input.Resource, catalogId)
+
+	if d.Get("validate_only").(bool) {
+		issues := lakeFormationGrantFeasibilityIssues(d.Get("principal").(string), &apiObject, d.Get("register_data_location").(bool), newLakeFormationGrantFeasibilityChecks(meta.(*AWSClient)))
+
+		for _, issue := range issues {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Lake Formation Permissions (validate only): %s", issue),
+			})
+		}
+
+		d.SetId(tflakeformation.PermissionsCreateID(aws.StringValue(input.Principal.DataLakePrincipalIdentifier), catalogId, input.Resource))
+		d.Set("feasibility_issues", issues)
+
+		return diags
+	}
+
+	// The Glue database and table are looked up once, when the permissions are created, rather than on every read.
+	if d.IsNewResource() {
+		if v := apiObject.TableWithColumns; v != nil {
+			missing, err := resourceAwsLakeFormationPermissionsMissingColumns(meta.(*AWSClient), v)
+
+			if err != nil {
+				return append(diags, diag.FromErr(fmt.Errorf("error reading Glue table (%s.%s): %w", aws.StringValue(v.DatabaseName), aws.StringValue(v.Name), err))...)
+			}
+
+			if len(missing) > 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Lake Formation Permissions reference columns that do not exist in table (%s.%s)", aws.StringValue(v.DatabaseName), aws.StringValue(v.Name)),
+					Detail:   fmt.Sprintf("Missing columns: %s. Lake Formation keeps grants on such columns, e.g. after a column rename, but they do not grant access to any data.", strings.Join(missing, ", ")),
+				})
+			}
+		}
+
+		target, err := resourceAwsLakeFormationPermissionsDatabaseTarget(meta.(*AWSClient), &apiObject)
+
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error reading Glue database (%s): %w", aws.StringValue(apiObject.Database.Name), err))...)
+		}
+
+		if err := d.Set("target_database", flattenLakeFormationDatabaseTarget(target)); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error setting target_database: %w", err))...)
+		}
+	}
+
+	if d.Get("register_data_location").(bool) && input.Resource.DataLocation != nil {
+		resourceArn := aws.StringValue(input.Resource.DataLocation.ResourceArn)
+
+		if err := lakeFormationRegisterDataLocation(conn, resourceArn, d.Get("register_data_location_role_arn").(string)); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error registering Lake Formation data location (%s): %w", resourceArn, err))...)
+		}
+	}
+
+	var output *lakeformation.GrantPermissionsOutput
+	retryErrors := &lakeFormationRetryErrors{}
+	err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutCreate), lakeFormationPollInterval(d), retryErrors.wrap(lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationGrantPermissionsRetryableError, func() error {
+		var err error
+		output, err = conn.GrantPermissionsWithContext(ctx, input)
+		return err
+	}))))
+
+	if isResourceTimeoutError(err) {
+		output, err = conn.GrantPermissionsWithContext(ctx, input)
+	}
+
+	if err != nil {
+		return append(diags, lakeFormationPermissionsFailureDiagnostics(fmt.Errorf("error creating Lake Formation Permissions (input: %v): %w", input, retryErrors.annotate(err)), aws.StringValue(input.Principal.DataLakePrincipalIdentifier), input.Resource)...)
+	}
+
+	if output == nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error creating Lake Formation Permissions: empty response"))...)
+	}
+
+	lakeFormationPermissionsNotify(ctx, lakeFormationPermissionsGrantedEvent(input))
+
+	d.SetId(tflakeformation.PermissionsCreateID(aws.StringValue(input.Principal.DataLakePrincipalIdentifier), catalogId, input.Resource))
+
+	return append(diags, resourceAwsLakeFormationPermissionsRead(ctx, d, meta)...)
+}
+
+func resourceAwsLakeFormationPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	var diags diag.Diagnostics
+
+	// Nothing was granted, so there is nothing to read.
+	if d.Get("validate_only").(bool) {
+		return nil
+	}
+
+	if v, ok := d.GetOk("databases"); ok && v.(*schema.Set).Len() > 0 {
+		return resourceAwsLakeFormationPermissionsReadDatabases(ctx, d, meta)
+	}
+
+	input := &lakeformation.ListPermissionsInput{
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+		},
+	}
+
+	if v, ok := d.GetOk("catalog_id"); ok {
+		input.CatalogId = aws.String(v.(string))
+	}
+
+	input.Resource = expandLakeFormationResource(d, true)
+	input.ResourceType = aws.String(tflakeformation.ListPermissionsResourceType(expandLakeFormationResourceType(d)))
+	matchResource := expandLakeFormationResource(d, false)
+	// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
+	var selectPermissionsResource *lakeformation.Resource
+	if !d.Get("skip_select_companion").(bool) {
+		selectPermissionsResource = expandLakeFormationResourceForSelectPermissions(d)
+	}
+
+	grantorCatalogId := meta.(*AWSClient).accountid
+	if input.CatalogId != nil {
+		grantorCatalogId = aws.StringValue(input.CatalogId)
+	}
+
+	// Resource blocks without a catalog ID refer to the catalog the grant is made in, which need not be the
+	// caller's, so Glue lookups use that catalog rather than defaulting to the account ID.
+	lookupResource := tflakeformation.ResourceWithEffectiveCatalogID(*matchResource, grantorCatalogId)
+
+	log.Printf("[DEBUG] Reading Lake Formation permissions: %v", input)
+	targetResource := expandLakeFormationDatabaseTarget(d.Get("target_database").([]interface{}))
+	ignoreColumnNameCase := d.Get("ignore_column_name_case").(bool)
+	ignoreTableNameCase := d.Get("ignore_table_name_case").(bool)
+	match := func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		if permission == nil || permission.Resource == nil {
+			return nil
+		}
+
+		entry := *permission
+
+		if ignoreTableNameCase {
+			entry.Resource = lakeFormationResourceWithConfigTableNameCase(matchResource, entry.Resource)
+		}
+
+		if ignoreColumnNameCase {
+			entry.Resource = lakeFormationResourceWithConfigColumnCase(matchResource, entry.Resource)
+		}
+
+		if apiObject := resourceAwsLakeFormationPermissionsMatch(d.Id(), matchResource, selectPermissionsResource, grantorCatalogId, &entry); apiObject != nil {
+			return apiObject
+		}
+
+		return resourceAwsLakeFormationPermissionsMatchTarget(d.Id(), matchResource, targetResource, grantorCatalogId, &entry)
+	}
+	collector := newLakeFormationPermissionsCollector(match)
+
+	retryErrors := &lakeFormationRetryErrors{}
+	err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutRead), lakeFormationPollInterval(d), retryErrors.wrap(lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationListPermissionsRetryableError, func() error {
+		collector = newLakeFormationPermissionsCollector(match)
+		return conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}))))
+
+	if isResourceTimeoutError(err) {
+		collector = newLakeFormationPermissionsCollector(match)
+		err = conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions: %w", retryErrors.annotate(err)))
+	}
+
+	principalResourcePermissions := tflakeformation.PermissionsAggregate(collector.matches)
+
+	if !d.IsNewResource() && len(principalResourcePermissions) == 0 {
+		// Replacing a table with a view of the same name drops the grants made on the table.
+		isView, err := resourceAwsLakeFormationPermissionsTableIsView(meta.(*AWSClient), &lookupResource)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions (%s) Glue table: %w", d.Id(), err))
+		}
+
+		if isView {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Lake Formation permissions (%s) table was replaced by a view", d.Id()),
+				Detail:   "The grants on the table were dropped when it was replaced, so the permissions are removed from state.",
+			})
+			d.SetId("")
+			return diags
+		}
+
+		// e.g. the principal was recreated with a different ARN, such as an IAM role moved to a new path
+		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(principalResourcePermissions) == 0 {
+		return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions: %s", "no permissions found"))
+	}
+
+	principalResourcePermissions, err = lakeFormationPermissionsResolveMultipleMatches(d.Id(), d.Get("multiple_matches").(string), principalResourcePermissions)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions: %w", err))
+	}
+
+	permissions := flattenLakeFormationPermissions(principalResourcePermissions)
+	grantPermissions := flattenLakeFormationGrantPermissions(principalResourcePermissions)
+	permissions = tflakeformation.PermissionsWithExpandedAll(expandLakeFormationResourceType(d), permissions, grantPermissions)
+	grantPermissions = tflakeformation.PermissionsWithExpandedAll(expandLakeFormationResourceType(d), grantPermissions, permissions)
+
+	// Revoking only the permissions outside of Terraform leaves an entry holding just the grant options.
+	if len(permissions) == 0 && len(grantPermissions) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Lake Formation permissions (%s) only hold grant options", d.Id()),
+			Detail:   fmt.Sprintf("The permissions were revoked outside of Terraform, leaving only the grant options (%s).", strings.Join(grantPermissions, ", ")),
+		})
+	}
+
+	d.Set("normalized_permissions", flattenLakeFormationNormalizedPermissions(principalResourcePermissions))
+	if lakeFormationPermissionsAnyResourceShared(principalResourcePermissions) {
+		d.Set("cross_account_status", resourceAwsLakeFormationPermissionsCrossAccountStatus(meta.(*AWSClient).ramconn, principalResourcePermissions))
+	} else {
+		d.Set("cross_account_status", "")
+	}
+	principal, effectivePrincipal := flattenLakeFormationPermissionsPrincipal(d.Get("principal").(string), principalResourcePermissions[0].Principal)
+	d.Set("catalog_id", grantorCatalogId)
+	d.Set("principal", principal)
+	d.Set("effective_principal", effectivePrincipal)
+	reportedPermissions := lakeFormationCollapseBroadPermissions(permissions, d.Get("permissions").(*schema.Set))
+	if err := d.Set("permissions_diff", []interface{}{flattenLakeFormationPermissionsDiff(aws.StringValueSlice(expandStringSet(d.Get("permissions").(*schema.Set))), reportedPermissions)}); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting permissions_diff: %w", err))
+	}
+	d.Set("permissions", reportedPermissions)
+	d.Set("permissions_with_grant_option", lakeFormationCollapseBroadPermissions(grantPermissions, d.Get("permissions_with_grant_option").(*schema.Set)))
+
+	if principalResourcePermissions[0].Resource.Catalog != nil {
+		d.Set("catalog_resource", true)
+	}
+
+	if principalResourcePermissions[0].Resource.DataLocation != nil {
+		d.Set("data_location", []interface{}{flattenLakeFormationDataLocationResource(principalResourcePermissions[0].Resource.DataLocation)})
+	} else {
+		d.Set("data_location", nil)
+	}
+
+	if principalResourcePermissions[0].Resource.Database != nil {
+		d.Set("database", []interface{}{flattenLakeFormationDatabaseResource(principalResourcePermissions[0].Resource.Database)})
+	} else {
+		d.Set("database", nil)
+	}
+
+	d.Set("has_companion_select", lakeFormationPermissionsHasCompanionSelect(expandLakeFormationResourceType(d), principalResourcePermissions))
+
+	tableBlock, tableWithColumnsBlock := flattenLakeFormationPermissionsTableBlocks(expandLakeFormationResourceType(d), principalResourcePermissions)
+	d.Set("table", tableBlock)
+	d.Set("table_with_columns", tableWithColumnsBlock)
+
+	return diags
+}
+
+func resourceAwsLakeFormationPermissionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+
+	if d.Get("validate_only").(bool) {
+		return nil
+	}
+
+	if v, ok := d.GetOk("databases"); ok && v.(*schema.Set).Len() > 0 {
+		return resourceAwsLakeFormationPermissionsDeleteDatabases(ctx, d, meta)
+	}
+
+	if err := lakeFormationCatalogRevokeAllowed(d.Get("catalog_resource").(bool), d.Get("allow_catalog_revoke").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &lakeformation.RevokePermissionsInput{
+		Permissions: expandStringSet(d.Get("permissions").(*schema.Set)),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+		},
+	}
+
+	if v, ok := d.GetOk("catalog_id"); ok {
+		input.CatalogId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("permissions_with_grant_option"); ok {
+		input.PermissionsWithGrantOption = expandStringSet(v.(*schema.Set))
+	}
+
+	input.Resource = expandLakeFormationResource(d, false)
+
+	retryErrors := &lakeFormationRetryErrors{}
+	err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutDelete), lakeFormationPollInterval(d), retryErrors.wrap(lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationRevokePermissionsRetryableError, func() error {
+		_, err := conn.RevokePermissionsWithContext(ctx, input)
+		return err
+	}))))
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.RevokePermissionsWithContext(ctx, input)
+	}
+
+	if err != nil {
+		return lakeFormationPermissionsFailureDiagnostics(fmt.Errorf("unable to revoke LakeFormation Permissions (input: %v): %w", input, retryErrors.annotate(err)), aws.StringValue(input.Principal.DataLakePrincipalIdentifier), input.Resource)
+	}
+
+	lakeFormationPermissionsNotify(ctx, lakeFormationPermissionsRevokedEvent(input))
+
+	if d.Get("revoke_all_on_destroy").(bool) {
+		grantorCatalogId := meta.(*AWSClient).accountid
+		if input.CatalogId != nil {
+			grantorCatalogId = aws.StringValue(input.CatalogId)
+		}
+
+		if err := lakeFormationRevokeAllPermissions(ctx, d, conn, input.CatalogId, grantorCatalogId, input.Principal, input.Resource); err != nil {
+			return lakeFormationPermissionsFailureDiagnostics(fmt.Errorf("unable to revoke all LakeFormation Permissions for principal (%s): %w", d.Get("principal").(string), err), aws.StringValue(input.Principal.DataLakePrincipalIdentifier), input.Resource)
+		}
+	}
+
+	return nil
+}
+
+// resourceAwsLakeFormationPermissionsCreateDatabases grants the permissions on every database in databases with a
+// single BatchGrantPermissions call. On update, the grants on removed databases are revoked and only the added
+// databases are granted, so rolling back a partial failure never revokes grants made by an earlier apply.
+func resourceAwsLakeFormationPermissionsCreateDatabases(ctx context.Context, d *schema.ResourceData, meta interface{}, input *lakeformation.GrantPermissionsInput) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogId := d.Get("catalog_id").(string)
+	principal := aws.StringValue(input.Principal.DataLakePrincipalIdentifier)
+
+	grantorCatalogId := meta.(*AWSClient).accountid
+	if catalogId != "" {
+		grantorCatalogId = catalogId
+	}
+
+	o, n := d.GetChange("databases")
+	oldDatabases, newDatabases := o.(*schema.Set), n.(*schema.Set)
+
+	if d.Get("validate_only").(bool) {
+		var issues []string
+		checks := newLakeFormationGrantFeasibilityChecks(meta.(*AWSClient))
+
+		for _, entry := range expandLakeFormationPermissionsDatabasesEntries(input, newDatabases.List(), grantorCatalogId) {
+			apiObject := tflakeformation.ResourceWithEffectiveCatalogID(*entry.Resource, grantorCatalogId)
+			issues = append(issues, lakeFormationGrantFeasibilityIssues(principal, &apiObject, false, checks)...)
+		}
+
+		var diags diag.Diagnostics
+
+		for _, issue := range issues {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Lake Formation Permissions (validate only): %s", issue),
+			})
+		}
+
+		d.SetId(tflakeformation.PermissionsDatabasesCreateID(principal, grantorCatalogId, expandLakeFormationDatabaseResources(newDatabases.List())))
+		d.Set("feasibility_issues", issues)
+
+		return diags
+	}
+
+	oldEntries := expandLakeFormationPermissionsDatabasesEntries(input, oldDatabases.List(), grantorCatalogId)
+	newEntries := expandLakeFormationPermissionsDatabasesEntries(input, newDatabases.List(), grantorCatalogId)
+
+	// Entries are compared by their grant key, so that only setting catalog_id to the account ID changes nothing.
+	revoke := lakeFormationBatchPermissionsEntriesDifference(oldEntries, newEntries)
+
+	if _, err := lakeFormationBatchRevokePermissions(ctx, conn, catalogId, revoke); err != nil {
+		return diag.FromErr(fmt.Errorf("error revoking Lake Formation Permissions (%s) on removed databases: %w", d.Id(), err))
+	}
+
+	lakeFormationPermissionsNotifyEntries(ctx, lakeFormationPermissionsEventRevoke, input.CatalogId, revoke)
+
+	grant := lakeFormationBatchPermissionsEntriesDifference(newEntries, oldEntries)
+
+	if _, err := lakeFormationBatchGrantPermissions(ctx, conn, catalogId, grant, true); err != nil {
+		// The databases granted by this call were rolled back, leaving only those granted before.
+		d.Set("databases", oldDatabases.Intersection(newDatabases).List())
+
+		return diag.FromErr(fmt.Errorf("error creating Lake Formation Permissions on databases: %w", err))
+	}
+
+	lakeFormationPermissionsNotifyEntries(ctx, lakeFormationPermissionsEventGrant, input.CatalogId, grant)
+
+	d.SetId(tflakeformation.PermissionsDatabasesCreateID(principal, grantorCatalogId, expandLakeFormationDatabaseResources(newDatabases.List())))
+
+	return resourceAwsLakeFormationPermissionsRead(ctx, d, meta)
+}
+
+// resourceAwsLakeFormationPermissionsReadDatabases lists the principal's database grants once and reconciles each
+// database in databases separately. A database that no longer holds every configured permission is dropped from
+// state, so that the next apply grants it again.
+func resourceAwsLakeFormationPermissionsReadDatabases(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+
+	input := &lakeformation.ListPermissionsInput{
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+		},
+		ResourceType: aws.String(lakeformation.DataLakeResourceTypeDatabase),
+	}
+
+	if v, ok := d.GetOk("catalog_id"); ok {
+		input.CatalogId = aws.String(v.(string))
+	}
+
+	grantorCatalogId := meta.(*AWSClient).accountid
+	if input.CatalogId != nil {
+		grantorCatalogId = aws.StringValue(input.CatalogId)
+	}
+
+	log.Printf("[DEBUG] Reading Lake Formation permissions: %v", input)
+	match := func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		if permission == nil || lakeFormationResourceTypeOf(permission.Resource) != lakeformation.DataLakeResourceTypeDatabase {
+			return nil
+		}
+
+		return permission.Resource
+	}
+	collector := newLakeFormationPermissionsCollector(match)
+
+	retryErrors := &lakeFormationRetryErrors{}
+	err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutRead), lakeFormationPollInterval(d), retryErrors.wrap(lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationListPermissionsRetryableError, func() error {
+		collector = newLakeFormationPermissionsCollector(match)
+		return conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}))))
+
+	if isResourceTimeoutError(err) {
+		collector = newLakeFormationPermissionsCollector(match)
+		err = conn.ListPermissionsPagesWithContext(ctx, input, collector.page)
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions: %w", retryErrors.annotate(err)))
+	}
+
+	granted, missing := lakeFormationPermissionsDatabasesGranted(d.Id(), d.Get("databases").(*schema.Set).List(), grantorCatalogId, d.Get("permissions").(*schema.Set), d.Get("permissions_with_grant_option").(*schema.Set), collector.matches)
+
+	if len(missing) > 0 && d.IsNewResource() {
+		return diag.FromErr(fmt.Errorf("error reading Lake Formation permissions: no permissions found on databases (%s)", strings.Join(missing, ", ")))
+	}
+
+	if len(granted) == 0 {
+		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if len(missing) > 0 {
+		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found on databases (%s), removing them from state", d.Id(), strings.Join(missing, ", "))
+	}
+
+	if err := d.Set("databases", granted); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting databases: %w", err))
+	}
+
+	d.Set("catalog_id", grantorCatalogId)
+
+	d.Set("data_location", nil)
+	d.Set("database", nil)
+	d.Set("table", nil)
+	d.Set("table_with_columns", nil)
+
+	return nil
+}
+
+func resourceAwsLakeFormationPermissionsDeleteDatabases(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).lakeformationconn
+	catalogId := d.Get("catalog_id").(string)
+
+	input := &lakeformation.GrantPermissionsInput{
+		Permissions: expandStringSet(d.Get("permissions").(*schema.Set)),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
+		},
+	}
+
+	grantorCatalogId := meta.(*AWSClient).accountid
+	if catalogId != "" {
+		input.CatalogId = aws.String(catalogId)
+		grantorCatalogId = catalogId
+	}
+
+	if v, ok := d.GetOk("permissions_with_grant_option"); ok {
+		input.PermissionsWithGrantOption = expandStringSet(v.(*schema.Set))
+	}
+
+	entries := expandLakeFormationPermissionsDatabasesEntries(input, d.Get("databases").(*schema.Set).List(), grantorCatalogId)
+
+	if _, err := lakeFormationBatchRevokePermissions(ctx, conn, catalogId, entries); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to revoke LakeFormation Permissions (%s) on databases: %w", d.Id(), err))
+	}
+
+	lakeFormationPermissionsNotifyEntries(ctx, lakeFormationPermissionsEventRevoke, input.CatalogId, entries)
+
+	if d.Get("revoke_all_on_destroy").(bool) {
+		for _, entry := range entries {
+			if err := lakeFormationRevokeAllPermissions(ctx, d, conn, input.CatalogId, grantorCatalogId, input.Principal, entry.Resource); err != nil {
+				return diag.FromErr(fmt.Errorf("unable to revoke all LakeFormation Permissions for principal (%s) on database (%s): %w", d.Get("principal").(string), aws.StringValue(entry.Id), err))
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandLakeFormationDatabaseResources(tfList []interface{}) []*lakeformation.DatabaseResource {
+	apiObjects := make([]*lakeformation.DatabaseResource, 0, len(tfList))
+
+	for _, tfMapRaw := range tfList {
+		if tfMap, ok := tfMapRaw.(map[string]interface{}); ok {
+			apiObjects = append(apiObjects, expandLakeFormationDatabaseResource(tfMap))
+		}
+	}
+
+	return apiObjects
+}
+
+// expandLakeFormationPermissionsDatabasesEntries returns a batch entry with the permissions of input for each
+// database block. Entries are identified by the resource key of the database, e.g. DATABASE,123456789012,db, so
+// that failures name the database.
+func expandLakeFormationPermissionsDatabasesEntries(input *lakeformation.GrantPermissionsInput, tfList []interface{}, grantorCatalogId string) []*lakeformation.BatchPermissionsRequestEntry {
+	apiObjects := make([]*lakeformation.BatchPermissionsRequestEntry, 0, len(tfList))
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		apiResource := &lakeformation.Resource{
+			Database: expandLakeFormationDatabaseResource(tfMap),
+		}
+
+		apiObjects = append(apiObjects, &lakeformation.BatchPermissionsRequestEntry{
+			Id:                         aws.String(tflakeformation.PermissionsResourceKey(grantorCatalogId, apiResource)),
+			Permissions:                input.Permissions,
+			PermissionsWithGrantOption: input.PermissionsWithGrantOption,
+			Principal:                  input.Principal,
+			Resource:                   apiResource,
+		})
+	}
+
+	return apiObjects
+}
+
+// lakeFormationPermissionsNotifyEntries notifies the permissions hook of each batch entry that was granted or revoked.
+func lakeFormationPermissionsNotifyEntries(ctx context.Context, action string, catalogId *string, entries []*lakeformation.BatchPermissionsRequestEntry) {
+	for _, entry := range entries {
+		lakeFormationPermissionsNotify(ctx, newLakeFormationPermissionsEvent(action, catalogId, entry.Principal, entry.Resource, entry.Permissions, entry.PermissionsWithGrantOption))
+	}
+}
+
+// lakeFormationPermissionsDatabasesGranted splits the database blocks into those on which the listed entries hold
+// every configured permission and grant option, and the names of those on which they do not.
+func lakeFormationPermissionsDatabasesGranted(id string, tfList []interface{}, grantorCatalogId string, permissions, permissionsWithGrantOption *schema.Set, apiObjects []*lakeformation.PrincipalResourcePermissions) ([]interface{}, []string) {
+	granted := make([]interface{}, 0, len(tfList))
+	var missing []string
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		matchResource := &lakeformation.Resource{
+			Database: expandLakeFormationDatabaseResource(tfMap),
+		}
+
+		var matches []*lakeformation.PrincipalResourcePermissions
+
+		for _, apiObject := range apiObjects {
+			if v := resourceAwsLakeFormationPermissionsMatch(id, matchResource, nil, grantorCatalogId, apiObject); v != nil {
+				matches = append(matches, lakeFormationPermissionsWithResource(apiObject, v))
+			}
+		}
+
+		matches = tflakeformation.PermissionsAggregate(matches)
+		reported := flattenLakeFormationPermissions(matches)
+		grantReported := flattenLakeFormationGrantPermissions(matches)
+		reported = tflakeformation.PermissionsWithExpandedAll(lakeformation.DataLakeResourceTypeDatabase, reported, grantReported)
+		grantReported = tflakeformation.PermissionsWithExpandedAll(lakeformation.DataLakeResourceTypeDatabase, grantReported, reported)
+
+		if len(matches) == 0 || !lakeFormationPermissionsHeld(permissions, reported) || !lakeFormationPermissionsHeld(permissionsWithGrantOption, grantReported) {
+			missing = append(missing, lakeFormationResourceIdentifier(matchResource))
+			continue
+		}
+
+		granted = append(granted, tfMap)
+	}
+
+	return granted, missing
+}
+
+// lakeFormationPermissionsHeld reports whether the reported permissions hold every configured permission, either
+// explicitly or through a broad permission such as ALL.
+func lakeFormationPermissionsHeld(configured *schema.Set, reported []string) bool {
+	for _, v := range reported {
+		for _, b := range lakeFormationBroadPermissions {
+			if v == b {
+				return true
+			}
+		}
+	}
+
+	var expected []string
+	if configured != nil {
+		expected = aws.StringValueSlice(expandStringSet(configured))
+	}
+
+	return len(lakeFormationStringsDifference(expected, reported)) == 0
+}
+
+const (
+	lakeFormationPermissionsEventGrant  = "GRANT"
+	lakeFormationPermissionsEventRevoke = "REVOKE"
+)
+
+// lakeFormationPermissionsEvent describes permissions that were successfully granted or revoked.
+type lakeFormationPermissionsEvent struct {
+	Action                     string
+	CatalogId                  string
+	Principal                  string
+	Resource                   *lakeformation.Resource
+	Permissions                []string
+	PermissionsWithGrantOption []string
+}
+
+// lakeFormationPermissionsHook is invoked after every successful grant and revoke, e.g. to forward the changes to
+// an audit log or an event bus. Implementations must not block, as they run inline with the apply.
+type lakeFormationPermissionsHook interface {
+	PermissionsChanged(ctx context.Context, event lakeFormationPermissionsEvent)
+}
+
+type lakeFormationPermissionsNoopHook struct{}
+
+func (lakeFormationPermissionsNoopHook) PermissionsChanged(context.Context, lakeFormationPermissionsEvent) {
+}
+
+// lakeFormationPermissionsEvents is the hook invoked by the aws_lakeformation_permissions resource. It does nothing
+// by default.
+var lakeFormationPermissionsEvents lakeFormationPermissionsHook = lakeFormationPermissionsNoopHook{}
+
+func lakeFormationPermissionsNotify(ctx context.Context, event lakeFormationPermissionsEvent) {
+	if lakeFormationPermissionsEvents == nil {
+		return
+	}
+
+	lakeFormationPermissionsEvents.PermissionsChanged(ctx, event)
+}
+
+func lakeFormationPermissionsGrantedEvent(input *lakeformation.GrantPermissionsInput) lakeFormationPermissionsEvent {
+	return newLakeFormationPermissionsEvent(lakeFormationPermissionsEventGrant, input.CatalogId, input.Principal, input.Resource, input.Permissions, input.PermissionsWithGrantOption)
+}
+
+func lakeFormationPermissionsRevokedEvent(input *lakeformation.RevokePermissionsInput) lakeFormationPermissionsEvent {
+	return newLakeFormationPermissionsEvent(lakeFormationPermissionsEventRevoke, input.CatalogId, input.Principal, input.Resource, input.Permissions, input.PermissionsWithGrantOption)
+}
+
+func newLakeFormationPermissionsEvent(action string, catalogId *string, principal *lakeformation.DataLakePrincipal, apiObject *lakeformation.Resource, permissions, permissionsWithGrantOption []*string) lakeFormationPermissionsEvent {
+	event := lakeFormationPermissionsEvent{
+		Action:                     action,
+		CatalogId:                  aws.StringValue(catalogId),
+		Resource:                   apiObject,
+		Permissions:                aws.StringValueSlice(permissions),
+		PermissionsWithGrantOption: aws.StringValueSlice(permissionsWithGrantOption),
+	}
+
+	if principal != nil {
+		event.Principal = aws.StringValue(principal.DataLakePrincipalIdentifier)
+	}
+
+	sort.Strings(event.Permissions)
+	sort.Strings(event.PermissionsWithGrantOption)
+
+	return event
+}
+
+// lakeFormationJsonDiagnosticsEnvVar enables JSON-encoded details on failed grants and revokes, e.g. for
+// machine-readable CI output.
+const lakeFormationJsonDiagnosticsEnvVar = "TF_AWS_LAKEFORMATION_JSON_DIAGNOSTICS"
+
+// lakeFormationPermissionsFailure is the JSON-encoded detail of a failed grant or revoke.
+type lakeFormationPermissionsFailure struct {
+	Principal    string `json:"principal"`
+	ResourceType string `json:"resource_type"`
+	Identifier   string `json:"identifier"`
+	ErrorCode    string `json:"error_code"`
+	RequestId    string `json:"request_id"`
+}
+
+// lakeFormationPermissionsFailureDiagnostics returns err as diagnostics, with a JSON-encoded detail when
+// TF_AWS_LAKEFORMATION_JSON_DIAGNOSTICS is set.
+func lakeFormationPermissionsFailureDiagnostics(err error, principal string, apiObject *lakeformation.Resource) diag.Diagnostics {
+	return expandLakeFormationPermissionsFailureDiagnostics(err, principal, apiObject, os.Getenv(lakeFormationJsonDiagnosticsEnvVar) != "")
+}
+
+func expandLakeFormationPermissionsFailureDiagnostics(err error, principal string, apiObject *lakeformation.Resource, jsonDetail bool) diag.Diagnostics {
+	if !jsonDetail {
+		return diag.FromErr(err)
+	}
+
+	failure := lakeFormationPermissionsFailure{
+		Principal: principal,
+	}
+
+	if apiObject != nil {
+		failure.ResourceType = lakeFormationResourceTypeOf(apiObject)
+		failure.Identifier = lakeFormationResourceIdentifier(apiObject)
+	}
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		failure.ErrorCode = awsErr.Code()
+	}
+
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) {
+		failure.RequestId = requestFailure.RequestID()
+	}
+
+	detail, marshalErr := json.Marshal(failure)
+
+	if marshalErr != nil {
+		log.Printf("[WARN] Error encoding Lake Formation permissions failure: %s", marshalErr)
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   string(detail),
+		},
+	}
+}
+
+// lakeFormationCatalogRevokeAllowed returns an error when permissions on the Data Catalog are to be revoked without
+// explicit confirmation, as revoking catalog-wide permissions such as CREATE_DATABASE can broadly remove access.
+func lakeFormationCatalogRevokeAllowed(catalogResource, allowCatalogRevoke bool) error {
+	if catalogResource && !allowCatalogRevoke {
+		return errors.New("refusing to revoke Lake Formation permissions on the Data Catalog: set allow_catalog_revoke to true and apply before destroying")
+	}
+
+	return nil
+}
+
+// lakeFormationRegisterDataLocation registers the data location with Lake Formation unless it is already registered.
+func lakeFormationRegisterDataLocation(conn *lakeformation.LakeFormation, resourceArn, roleArn string) error {
+	_, err := conn.DescribeResource(&lakeformation.DescribeResourceInput{
+		ResourceArn: aws.String(resourceArn),
+	})
+
+	if err == nil {
+		return nil
+	}
+
+	if !tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return err
+	}
+
+	input := expandLakeFormationRegisterDataLocationInput(resourceArn, roleArn)
+
+	log.Printf("[DEBUG] Registering Lake Formation data location: %s", input)
+	_, err = conn.RegisterResource(input)
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeAlreadyExistsException) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := lakeformationwaiter.ResourceRegistered(conn, resourceArn); err != nil {
+		return fmt.Errorf("error waiting for registration: %w", err)
+	}
+
+	return nil
+}
+
+// expandLakeFormationRegisterDataLocationInput returns the request registering the data location with the role,
+// or with the Lake Formation service-linked role when no role is given.
+func expandLakeFormationRegisterDataLocationInput(resourceArn, roleArn string) *lakeformation.RegisterResourceInput {
+	input := &lakeformation.RegisterResourceInput{
+		ResourceArn: aws.String(resourceArn),
+	}
+
+	if roleArn != "" {
+		input.RoleArn = aws.String(roleArn)
+	} else {
+		input.UseServiceLinkedRole = aws.Bool(true)
+	}
+
+	return input
+}
+
+// resourceAwsLakeFormationPermissionsMatch returns the resource of a listed entry that describes the configured
+// grant on matchResource, normalized to the configuration, or nil when the entry does not match.
+// selectPermissionsResource is the companion table with columns resource of a table SELECT grant, or nil when the
+// companion is not matched. The entry itself is left as is.
+func resourceAwsLakeFormationPermissionsMatch(id string, matchResource, selectPermissionsResource *lakeformation.Resource, grantorCatalogId string, permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+	if permission == nil || permission.Resource == nil {
+		return nil
+	}
+
+	// ListPermissions can omit the catalog ID, which then refers to the catalog the grant was made in.
+	out := tflakeformation.ResourceWithEffectiveCatalogID(*permission.Resource, grantorCatalogId)
+
+	// So does a resource block without a catalog ID. Resolving it up front, instead of adopting the catalog ID of
+	// whichever entry is compared first, keeps identical grants in another catalog from matching.
+	in := tflakeformation.ResourceWithEffectiveCatalogID(*matchResource, grantorCatalogId)
+	matchResource = &in
+
+	if selectPermissionsResource != nil {
+		v := tflakeformation.ResourceWithEffectiveCatalogID(*selectPermissionsResource, grantorCatalogId)
+		selectPermissionsResource = &v
+	}
+
+	// Transitional responses can carry both column names and a column wildcard; keep the configured one.
+	if matchResource.TableWithColumns != nil && out.TableWithColumns != nil {
+		out.TableWithColumns = tflakeformation.TableWithColumnsResourceForConfig(matchResource.TableWithColumns, out.TableWithColumns)
+	}
+
+	if tflakeformation.ResourceEqual(*matchResource, out) {
+		return &out
+	}
+
+	// Grants shared through AWS RAM may echo the sharer's catalog ID rather than the resource owner's. Depending on
+	// the catalog's cross-account version, the same grant is reported once per resource share (version 1) or once
+	// (version 3), so the configured catalog ID is kept to let both shapes aggregate into a single entry.
+	if tflakeformation.PermissionsIsResourceShared(permission) && tflakeformation.SharedResourceEqual(*matchResource, out, grantorCatalogId) {
+		v := tflakeformation.ResourceWithCatalogID(out, tflakeformation.ResourceCatalogID(matchResource))
+		return &v
+	}
+
+	// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
+	if selectPermissionsResource != nil && tflakeformation.SelectResourceEqual(*selectPermissionsResource, out) {
+		return &out
+	}
+
+	// A grant on a single named table never satisfies a wildcard table configuration.
+	if tflakeformation.WildcardTableMismatch(matchResource, &out) {
+		log.Printf("[WARN] Lake Formation permissions (%s) configure all tables in database (%s) but AWS returned a grant on table (%s) instead of a wildcard grant; ignoring it", id, aws.StringValue(out.Table.DatabaseName), aws.StringValue(out.Table.Name))
+	}
+
+	// Nor does a wildcard grant satisfy a named table configuration, even though it covers the table.
+	if tflakeformation.WildcardTableCovers(matchResource, &out) {
+		log.Printf("[WARN] Lake Formation permissions (%s) configure table (%s) but AWS returned a grant on all tables in database (%s) instead of a grant on the table; ignoring it", id, aws.StringValue(matchResource.Table.Name), aws.StringValue(out.Table.DatabaseName))
+	}
+
+	return nil
+}
+
+// lakeFormationPermissionsCollector filters ListPermissions results one page at a time, keeping only the
+// matching entries, so that principals with very many grants don't hold every page in memory.
+type lakeFormationPermissionsCollector struct {
+	match   func(*lakeformation.PrincipalResourcePermissions) *lakeformation.Resource
+	matches []*lakeformation.PrincipalResourcePermissions
+}
+
+// newLakeFormationPermissionsCollector returns a collector keeping the entries for which match returns a resource.
+// Each kept entry is a copy carrying that resource, e.g. normalized to the configuration.
+func newLakeFormationPermissionsCollector(match func(*lakeformation.PrincipalResourcePermissions) *lakeformation.Resource) *lakeFormationPermissionsCollector {
+	return &lakeFormationPermissionsCollector{
+		match: match,
+	}
+}
+
+// page is a ListPermissionsPages callback.
+func (c *lakeFormationPermissionsCollector) page(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
+	if resp == nil {
+		return !lastPage
+	}
+
+	for _, permission := range resp.PrincipalResourcePermissions {
+		if apiObject := c.match(permission); apiObject != nil {
+			c.matches = append(c.matches, lakeFormationPermissionsWithResource(permission, apiObject))
+		}
+	}
+
+	return !lastPage
+}
+
+// lakeFormationPermissionsWithResource returns a copy of the entry on apiObject.
+func lakeFormationPermissionsWithResource(permission *lakeformation.PrincipalResourcePermissions, apiObject *lakeformation.Resource) *lakeformation.PrincipalResourcePermissions {
+	v := *permission
+	v.Resource = apiObject
+
+	return &v
+}
+
+// lakeFormationRevokeAllPermissions revokes every permission the principal holds on exactly the resource, including
+// grants not managed by Terraform. Grants on other resources returned by the listing, such as the columns of a
+// table, are left alone.
+func lakeFormationRevokeAllPermissions(ctx context.Context, d *schema.ResourceData, conn *lakeformation.LakeFormation, catalogId *string, grantorCatalogId string, principal *lakeformation.DataLakePrincipal, apiObject *lakeformation.Resource) error {
+	input := &lakeformation.ListPermissionsInput{
+		CatalogId:    catalogId,
+		Principal:    principal,
+		Resource:     apiObject,
+		ResourceType: aws.String(tflakeformation.ListPermissionsResourceType(lakeFormationResourceTypeOf(apiObject))),
+	}
+
+	if v := apiObject.TableWithColumns; v != nil {
+		// ListPermissions does not support getting privileges by tables with columns. Instead,
+		// use the table and keep only the entries on the table with columns below.
+		input.Resource = &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    v.CatalogId,
+				DatabaseName: v.DatabaseName,
+				Name:         v.Name,
+			},
+		}
+	}
+
+	var principalResourcePermissions []*lakeformation.PrincipalResourcePermissions
+
+	err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutDelete), lakeFormationPollInterval(d), lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationRetryableError, func() error {
+		principalResourcePermissions = nil
+
+		return conn.ListPermissionsPagesWithContext(ctx, input, func(page *lakeformation.ListPermissionsOutput, lastPage bool) bool {
+			principalResourcePermissions = append(principalResourcePermissions, page.PrincipalResourcePermissions...)
+			return !lastPage
+		})
+	})))
+
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, revokeInput := range expandLakeFormationRevokeAllPermissionsInputs(catalogId, grantorCatalogId, apiObject, principalResourcePermissions) {
+		log.Printf("[DEBUG] Revoking Lake Formation permissions: %s", revokeInput)
+		err := lakeFormationRetry(ctx, d.Timeout(schema.TimeoutDelete), lakeFormationPollInterval(d), lakeFormationRetryLimit(d.Get("max_retries").(int), lakeFormationRetryFunc(isLakeFormationRevokePermissionsRetryableError, func() error {
+			_, err := conn.RevokePermissionsWithContext(ctx, revokeInput)
+			return err
+		})))
+
+		if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandLakeFormationRevokeAllPermissionsInputs returns one revoke request for each listed entry on exactly the
+// resource that still holds permissions.
+func expandLakeFormationRevokeAllPermissionsInputs(catalogId *string, grantorCatalogId string, target *lakeformation.Resource, apiObjects []*lakeformation.PrincipalResourcePermissions) []*lakeformation.RevokePermissionsInput {
+	var inputs []*lakeformation.RevokePermissionsInput
+
+	key := tflakeformation.PermissionsResourceKey(grantorCatalogId, target)
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil || apiObject.Principal == nil {
+			continue
+		}
+
+		if len(apiObject.Permissions) == 0 && len(apiObject.PermissionsWithGrantOption) == 0 {
+			continue
+		}
+
+		if tflakeformation.PermissionsResourceKey(grantorCatalogId, apiObject.Resource) != key {
+			continue
+		}
+
+		inputs = append(inputs, &lakeformation.RevokePermissionsInput{
+			CatalogId:                  catalogId,
+			Permissions:                apiObject.Permissions,
+			PermissionsWithGrantOption: apiObject.PermissionsWithGrantOption,
+			Principal:                  apiObject.Principal,
+			Resource:                   apiObject.Resource,
+		})
+	}
+
+	return inputs
+}
+
+// resourceAwsLakeFormationPermissionsMissingColumns returns the granted column names that do not exist in the
+// table, e.g. after a column rename. No columns are returned when the table does not exist.
+func resourceAwsLakeFormationPermissionsMissingColumns(client *AWSClient, apiObject *lakeformation.TableWithColumnsResource) ([]string, error) {
+	if apiObject == nil || len(apiObject.ColumnNames) == 0 {
+		return nil, nil
+	}
+
+	catalogId := client.accountid
+	if v := aws.StringValue(apiObject.CatalogId); v != "" {
+		catalogId = v
+	}
+
+	output, err := gluefinder.TableByName(client.glueconn, catalogId, aws.StringValue(apiObject.DatabaseName), aws.StringValue(apiObject.Name))
+
+	if tfawserr.ErrCodeEquals(err, glue.ErrCodeEntityNotFoundException) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return lakeFormationMissingColumns(apiObject.ColumnNames, output.Table), nil
+}
+
+// glueTableTypeVirtualView is the Glue table type of a view. There is no glue package constant for it.
+const glueTableTypeVirtualView = "VIRTUAL_VIEW"
+
+// resourceAwsLakeFormationPermissionsTableIsView reports whether the table or table with columns resource now
+// refers to a Glue view.
+func resourceAwsLakeFormationPermissionsTableIsView(client *AWSClient, apiObject *lakeformation.Resource) (bool, error) {
+	if apiObject == nil {
+		return false, nil
+	}
+
+	var catalogId, databaseName, name *string
+
+	switch {
+	case apiObject.Table != nil && !tflakeformation.TableResourceIsWildcard(apiObject.Table):
+		catalogId, databaseName, name = apiObject.Table.CatalogId, apiObject.Table.DatabaseName, apiObject.Table.Name
+	case apiObject.TableWithColumns != nil:
+		catalogId, databaseName, name = apiObject.TableWithColumns.CatalogId, apiObject.TableWithColumns.DatabaseName, apiObject.TableWithColumns.Name
+	default:
+		return false, nil
+	}
+
+	if aws.StringValue(catalogId) == "" {
+		catalogId = aws.String(client.accountid)
+	}
+
+	output, err := gluefinder.TableByName(client.glueconn, aws.StringValue(catalogId), aws.StringValue(databaseName), aws.StringValue(name))
+
+	if tfawserr.ErrCodeEquals(err, glue.ErrCodeEntityNotFoundException) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if output == nil {
+		return false, nil
+	}
+
+	return lakeFormationTableIsView(output.Table), nil
+}
+
+// resourceAwsLakeFormationPermissionsDatabaseTarget returns the shared database that a database resource link
+// (an alias of a database shared from another account) refers to, or nil when the database is not a resource link
+// or does not exist.
+func resourceAwsLakeFormationPermissionsDatabaseTarget(client *AWSClient, apiObject *lakeformation.Resource) (*lakeformation.Resource, error) {
+	if apiObject == nil || apiObject.Database == nil {
+		return nil, nil
+	}
+
+	catalogId := apiObject.Database.CatalogId
+	if aws.StringValue(catalogId) == "" {
+		catalogId = aws.String(client.accountid)
+	}
+
+	output, err := client.glueconn.GetDatabase(&glue.GetDatabaseInput{
+		CatalogId: catalogId,
+		Name:      apiObject.Database.Name,
+	})
+
+	if tfawserr.ErrCodeEquals(err, glue.ErrCodeEntityNotFoundException) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return lakeFormationDatabaseTargetResource(output.Database), nil
+}
+
+// expandLakeFormationDatabaseTarget returns the target_database block as a database resource.
+func expandLakeFormationDatabaseTarget(tfList []interface{}) *lakeformation.Resource {
+	if len(tfList) == 0 || tfList[0] == nil {
+		return nil
+	}
+
+	return &lakeformation.Resource{
+		Database: expandLakeFormationDatabaseResource(tfList[0].(map[string]interface{})),
+	}
+}
+
+func flattenLakeFormationDatabaseTarget(apiObject *lakeformation.Resource) []interface{} {
+	if apiObject == nil || apiObject.Database == nil {
+		return nil
+	}
+
+	return []interface{}{flattenLakeFormationDatabaseResource(apiObject.Database)}
+}
+
+// lakeFormationDatabaseTargetResource returns the target of a Glue database resource link as a database resource.
+func lakeFormationDatabaseTargetResource(database *glue.Database) *lakeformation.Resource {
+	if database == nil || database.TargetDatabase == nil {
+		return nil
+	}
+
+	return &lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			CatalogId: database.TargetDatabase.CatalogId,
+			Name:      database.TargetDatabase.DatabaseName,
+		},
+	}
+}
+
+// resourceAwsLakeFormationPermissionsMatchTarget returns the configured resource link when the entry is a grant on
+// targetResource, the shared database that the resource link refers to, or nil otherwise. A matching entry is
+// reported on the resource link, rather than on the original database name, so that it reconciles without drift.
+func resourceAwsLakeFormationPermissionsMatchTarget(id string, matchResource, targetResource *lakeformation.Resource, grantorCatalogId string, permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+	if targetResource == nil || resourceAwsLakeFormationPermissionsMatch(id, targetResource, nil, grantorCatalogId, permission) == nil {
+		return nil
+	}
+
+	v := tflakeformation.ResourceWithEffectiveCatalogID(*matchResource, grantorCatalogId)
+
+	return &v
+}
+
+// lakeFormationTableIsView reports whether the Glue table is a view.
+func lakeFormationTableIsView(table *glue.TableData) bool {
+	if table == nil {
+		return false
+	}
+
+	return aws.StringValue(table.TableType) == glueTableTypeVirtualView
+}
+
+// lakeFormationMissingColumns returns the granted column names that are neither columns nor partition keys of the table.
+func lakeFormationMissingColumns(columnNames []*string, table *glue.TableData) []string {
+	if table == nil {
+		return nil
+	}
+
+	existing := make(map[string]bool)
+
+	if table.StorageDescriptor != nil {
+		for _, column := range table.StorageDescriptor.Columns {
+			existing[strings.ToLower(aws.StringValue(column.Name))] = true
+		}
+	}
+
+	for _, column := range table.PartitionKeys {
+		existing[strings.ToLower(aws.StringValue(column.Name))] = true
+	}
+
+	var missing []string
+
+	for _, v := range columnNames {
+		// Glue stores column names in lower case.
+		if !existing[strings.ToLower(aws.StringValue(v))] {
+			missing = append(missing, aws.StringValue(v))
+		}
+	}
+
+	return missing
+}
+
+// lakeFormationPrincipalEquivalent reports whether the returned principal identifies the same principal as the
+// configured one. A whole-account grant to a bare account ID can be reported as the account's root ARN, and an
+// assumed role session is reported without its session name.
+func lakeFormationPrincipalEquivalent(configured, returned string) bool {
+	if configured == returned {
+		return true
+	}
+
+	if stripped, roleName, ok := lakeFormationAssumedRoleSessionPrincipal(configured); ok {
+		if returned == stripped {
+			return true
+		}
+
+		configuredARN, _ := arn.Parse(configured)
+		returnedARN, err := arn.Parse(returned)
+
+		if err != nil || returnedARN.Service != "iam" || returnedARN.Partition != configuredARN.Partition || returnedARN.AccountID != configuredARN.AccountID {
+			return false
+		}
+
+		// Assumed role ARNs carry no path, so the role can be reported with any path.
+		return strings.HasPrefix(returnedARN.Resource, "role/") && strings.HasSuffix(returnedARN.Resource, "/"+roleName)
+	}
+
+	if _, errs := validateAwsAccountId(configured, "principal"); len(errs) > 0 {
+		return false
+	}
+
+	parsedARN, err := arn.Parse(returned)
+
+	if err != nil {
+		return false
+	}
+
+	return parsedARN.Service == "iam" && parsedARN.AccountID == configured && parsedARN.Resource == "root"
+}
+
+// lakeFormationAssumedRoleSessionPrincipal returns the principal without its session name and the role name when
+// principal is an assumed role session, e.g. arn:aws:sts::123456789012:assumed-role/example for
+// arn:aws:sts::123456789012:assumed-role/example/session.
+func lakeFormationAssumedRoleSessionPrincipal(principal string) (string, string, bool) {
+	parsedARN, err := arn.Parse(principal)
+
+	if err != nil || parsedARN.Service != "sts" {
+		return "", "", false
+	}
+
+	parts := strings.Split(parsedARN.Resource, "/")
+
+	if len(parts) != 3 || parts[0] != "assumed-role" || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+
+	parsedARN.Resource = strings.Join(parts[:2], "/")
+
+	return parsedARN.String(), parts[1], true
+}
+
+// flattenLakeFormationPermissionsPrincipal returns the principal to keep in state, which is the configured principal
+// unless AWS reports a different one, and the principal as canonicalized by AWS.
+func flattenLakeFormationPermissionsPrincipal(configured string, apiObject *lakeformation.DataLakePrincipal) (string, string) {
+	if apiObject == nil {
+		return configured, configured
+	}
+
+	effective := aws.StringValue(apiObject.DataLakePrincipalIdentifier)
+
+	if lakeFormationPrincipalEquivalent(configured, effective) {
+		return configured, effective
+	}
+
+	return effective, effective
+}
+
+// lakeFormationPollInterval returns the configured interval between propagation retries, or 0 for the default backoff.
+func lakeFormationPollInterval(d *schema.ResourceData) time.Duration {
+	v, ok := d.GetOk("poll_interval")
+
+	if !ok {
+		return 0
+	}
+
+	pollInterval, err := time.ParseDuration(v.(string))
+
+	if err != nil {
+		log.Printf("[WARN] Error parsing poll_interval, using default backoff")
+		return 0
+	}
+
+	return pollInterval
+}
+
+// lakeFormationRetry behaves like resource.RetryContext but waits pollInterval between attempts when it is set,
+// instead of the default exponential backoff.
+func lakeFormationRetry(ctx context.Context, timeout, pollInterval time.Duration, f resource.RetryFunc) error {
+	if pollInterval <= 0 {
+		return resource.RetryContext(ctx, timeout, f)
+	}
+
+	var resultErr error
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"retryableerror"},
+		Target:       []string{"success"},
+		Timeout:      timeout,
+		PollInterval: pollInterval,
+		Refresh: func() (interface{}, string, error) {
+			rerr := f()
+
+			if rerr == nil {
+				resultErr = nil
+				return 42, "success", nil
+			}
+
+			resultErr = rerr.Err
+
+			if rerr.Retryable {
+				return 42, "retryableerror", nil
+			}
+
+			return nil, "quit", rerr.Err
+		},
+	}
+
+	_, waitErr := stateConf.WaitForStateContext(ctx)
+
+	if timeoutErr, ok := waitErr.(*resource.TimeoutError); ok {
+		if timeoutErr.LastError == nil {
+			timeoutErr.LastError = resultErr
+		}
+		return timeoutErr
+	}
+
+	if resultErr == nil {
+		return waitErr
+	}
+
+	return resultErr
+}
+
+// lakeFormationRetryLimit returns a retry function that stops retrying f, returning its last error as
+// non-retryable, once f has been retried maxRetries times. A maxRetries of 0 leaves f unlimited.
+func lakeFormationRetryLimit(maxRetries int, f resource.RetryFunc) resource.RetryFunc {
+	if maxRetries <= 0 {
+		return f
+	}
+
+	var retries int
+
+	return func() *resource.RetryError {
+		rerr := f()
+
+		if rerr == nil || !rerr.Retryable {
+			return rerr
+		}
+
+		if retries >= maxRetries {
+			return resource.NonRetryableError(fmt.Errorf("giving up after %d retries: %w", retries, rerr.Err))
+		}
+
+		retries++
+
+		return rerr
+	}
+}
+
+// lakeFormationRetryErrors records the distinct errors returned by the attempts of a retry loop, so that the
+// final error also reports what failed in earlier attempts.
+type lakeFormationRetryErrors struct {
+	messages []string
+}
+
+// wrap returns a retry function that records the error of each failed attempt of f.
+func (e *lakeFormationRetryErrors) wrap(f resource.RetryFunc) resource.RetryFunc {
+	return func() *resource.RetryError {
+		rerr := f()
+
+		if rerr != nil {
+			e.record(rerr.Err)
+		}
+
+		return rerr
+	}
+}
+
+func (e *lakeFormationRetryErrors) record(err error) {
+	if err == nil {
+		return
+	}
+
+	v := err.Error()
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		v = fmt.Sprintf("%s: %s", awsErr.Code(), awsErr.Message())
+	}
+
+	for _, existing := range e.messages {
+		if existing == v {
+			return
+		}
+	}
+
+	e.messages = append(e.messages, v)
+}
+
+// annotate returns err with the distinct errors seen across all attempts appended, when there was more than one.
+func (e *lakeFormationRetryErrors) annotate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	e.record(err)
+
+	if len(e.messages) < 2 {
+		return err
+	}
+
+	return fmt.Errorf("%w (errors across retries: %s)", err, strings.Join(e.messages, "; "))
+}
+
+// isLakeFormationGrantPermissionsRetryableError reports whether a GrantPermissions error is expected to clear
+// once a newly created principal or resource has propagated.
+func isLakeFormationGrantPermissionsRetryableError(err error) bool {
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
+		return true
+	}
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Grantee has no permissions") {
+		return true
+	}
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "register the S3 path") {
+		return true
+	}
+	// e.g. granting on a database or table created moments before
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Resource does not exist") {
+		return true
+	}
+	if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") {
+		return true
+	}
+	if isAWSErr(err, "AccessDeniedException", "is not authorized to access requested permissions") {
+		return true
+	}
+
+	return isLakeFormationRetryableError(err)
+}
+
+// isLakeFormationRevokePermissionsRetryableError reports whether a RevokePermissions error is transient.
+func isLakeFormationRevokePermissionsRetryableError(err error) bool {
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "register the S3 path") {
+		return true
+	}
+	if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") {
+		return true
+	}
+
+	return isLakeFormationRetryableError(err)
+}
+
+// isLakeFormationListPermissionsRetryableError reports whether a ListPermissions error is transient, e.g. while a
+// new principal propagates.
+func isLakeFormationListPermissionsRetryableError(err error) bool {
+	if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
+		return true
+	}
+
+	return isLakeFormationRetryableError(err)
+}
+
+// isLakeFormationRetryableError reports whether err is transient for any Lake Formation call.
+func isLakeFormationRetryableError(err error) bool {
+	return isLakeFormationThrottlingError(err) || isLakeFormationOperationTimeoutError(err)
+}
+
+// lakeFormationRetryFunc returns a retry function that calls f once, treating the errors for which retryable
+// reports true as retryable.
+func lakeFormationRetryFunc(retryable func(error) bool, f func() error) resource.RetryFunc {
+	return func() *resource.RetryError {
+		err := f()
+
+		if err == nil {
+			return nil
+		}
+
+		if retryable(err) {
+			return resource.RetryableError(err)
+		}
+
+		return resource.NonRetryableError(err)
+	}
+}
+
+// lakeFormationErrCodeThrottlingException is returned when Lake Formation API requests are throttled.
+// There is no lakeformation package constant for this error code.
+const lakeFormationErrCodeThrottlingException = "ThrottlingException"
+
+// isLakeFormationThrottlingError reports whether err is a Lake Formation throttling error that is safe to retry.
+func isLakeFormationThrottlingError(err error) bool {
+	return isAWSErr(err, lakeFormationErrCodeThrottlingException, "")
+}
+
+// isLakeFormationOperationTimeoutError reports whether err is a Lake Formation operation timeout, which is
+// returned under load and is safe to retry.
+func isLakeFormationOperationTimeoutError(err error) bool {
+	return isAWSErr(err, lakeformation.ErrCodeOperationTimeoutException, "")
+}
+
+func resourceAwsLakeFormationPermissionsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	principal, resourceType, catalogId, apiObjects, err := tflakeformation.PermissionsParseID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if catalogId == "" {
+		catalogId = meta.(*AWSClient).accountid
+	}
+
+	d.Set("principal", principal)
+
+	switch resourceType {
+	case tflakeformation.PermissionsResourceTypeDatabases:
+		databases := make([]*lakeformation.DatabaseResource, 0, len(apiObjects))
+		tfList := make([]interface{}, 0, len(apiObjects))
+
+		for _, apiObject := range apiObjects {
+			databases = append(databases, apiObject.Database)
+			tfMap := flattenLakeFormationDatabaseResource(apiObject.Database)
+
+			// Database blocks are kept as configured, where the account's own catalog is usually left out.
+			if tfMap["catalog_id"] == meta.(*AWSClient).accountid {
+				tfMap["catalog_id"] = ""
+			}
+
+			tfList = append(tfList, tfMap)
+		}
+
+		d.Set("databases", tfList)
+		d.SetId(tflakeformation.PermissionsDatabasesCreateID(principal, catalogId, databases))
+	default:
+		apiObject := tflakeformation.ResourceWithEffectiveCatalogID(*apiObjects[0], catalogId)
+
+		target, err := resourceAwsLakeFormationPermissionsDatabaseTarget(meta.(*AWSClient), &apiObject)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading Glue database (%s): %w", aws.StringValue(apiObject.Database.Name), err)
+		}
+
+		d.Set("target_database", flattenLakeFormationDatabaseTarget(target))
+
+		switch {
+		case apiObject.Catalog != nil:
+			d.Set("catalog_resource", true)
+			d.Set("catalog_id", catalogId)
+		case apiObject.DataLocation != nil:
+			d.Set("data_location", []interface{}{flattenLakeFormationDataLocationResource(apiObject.DataLocation)})
+		case apiObject.Database != nil:
+			d.Set("database", []interface{}{flattenLakeFormationDatabaseResource(apiObject.Database)})
+		case apiObject.Table != nil:
+			d.Set("table", []interface{}{flattenLakeFormationTableResource(apiObject.Table)})
+		case apiObject.TableWithColumns != nil:
+			d.Set("table_with_columns", []interface{}{flattenLakeFormationTableWithColumnsResource(apiObject.TableWithColumns)})
+		}
+
+		if apiObject.Catalog == nil {
+			d.Set("catalog_resource", false)
+		}
+
+		d.SetId(tflakeformation.PermissionsCreateID(principal, catalogId, &apiObject))
+	}
+
+	d.Set("allow_catalog_revoke", false)
+	d.Set("check_principal_exists", false)
+	d.Set("ignore_column_name_case", false)
+	d.Set("ignore_table_name_case", false)
+	d.Set("multiple_matches", lakeFormationMultipleMatchesError)
+	d.Set("register_data_location", false)
+	d.Set("revoke_all_on_destroy", false)
+	d.Set("skip_select_companion", false)
+	d.Set("validate_only", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// lakeFormationResourceIdentifier returns a short, readable identifier of the resource.
+func lakeFormationResourceIdentifier(apiObject *lakeformation.Resource) string {
+	if apiObject == nil {
+		return ""
+	}
+
+	switch {
+	case apiObject.Catalog != nil:
+		return "*"
+	case apiObject.DataLocation != nil:
+		return aws.StringValue(apiObject.DataLocation.ResourceArn)
+	case apiObject.Database != nil:
+		return aws.StringValue(apiObject.Database.Name)
+	case apiObject.Table != nil:
+		if tflakeformation.TableResourceIsWildcard(apiObject.Table) {
+			return aws.StringValue(apiObject.Table.DatabaseName) + ".*"
+		}
+		return aws.StringValue(apiObject.Table.DatabaseName) + "." + aws.StringValue(apiObject.Table.Name)
+	case apiObject.TableWithColumns != nil:
+		return aws.StringValue(apiObject.TableWithColumns.DatabaseName) + "." + aws.StringValue(apiObject.TableWithColumns.Name)
+	}
+
+	return ""
+}
+
+// lakeFormationIamPrincipalLookup returns a lookup for lakeFormationIamPrincipalWarning that gets the IAM role or user.
+func lakeFormationIamPrincipalLookup(conn *iam.IAM) func(principalType, name string) error {
+	return func(principalType, name string) error {
+		var err error
+
+		switch principalType {
+		case "role":
+			_, err = conn.GetRole(&iam.GetRoleInput{RoleName: aws.String(name)})
+		case "user":
+			_, err = conn.GetUser(&iam.GetUserInput{UserName: aws.String(name)})
+		}
+
+		return err
+	}
+}
+
+// lakeFormationGrantFeasibilityChecks looks up what a grant depends on. Each lookup returns an EntityNotFoundException
+// error, or NoSuchEntity for IAM principals, when the looked up entity does not exist.
+type lakeFormationGrantFeasibilityChecks struct {
+	dataLocation func(resourceArn string) error
+	database     func(catalogId, name string) error
+	principal    func(principalType, name string) error
+	table        func(catalogId, databaseName, name string) error
+}
+
+func newLakeFormationGrantFeasibilityChecks(client *AWSClient) lakeFormationGrantFeasibilityChecks {
+	return lakeFormationGrantFeasibilityChecks{
+		dataLocation: func(resourceArn string) error {
+			_, err := client.lakeformationconn.DescribeResource(&lakeformation.DescribeResourceInput{
+				ResourceArn: aws.String(resourceArn),
+			})
+
+			return err
+		},
+		database: func(catalogId, name string) error {
+			_, err := client.glueconn.GetDatabase(&glue.GetDatabaseInput{
+				CatalogId: aws.String(catalogId),
+				Name:      aws.String(name),
+			})
+
+			return err
+		},
+		principal: lakeFormationIamPrincipalLookup(client.iamconn),
+		table: func(catalogId, databaseName, name string) error {
+			_, err := gluefinder.TableByName(client.glueconn, catalogId, databaseName, name)
+
+			return err
+		},
+	}
+}
+
+// lakeFormationGrantFeasibilityIssues returns the reasons the grant would fail, found on a best-effort basis: the
+// IAM role or user principal, the registration of the data location, and the Glue database or table must exist.
+// Lookups that fail for other reasons, e.g. without permission to look up the entity, are skipped.
+func lakeFormationGrantFeasibilityIssues(principal string, apiObject *lakeformation.Resource, registerDataLocation bool, checks lakeFormationGrantFeasibilityChecks) []string {
+	var issues []string
+
+	if warning := lakeFormationIamPrincipalWarning(principal, checks.principal); warning != "" {
+		issues = append(issues, warning)
+	}
+
+	if apiObject == nil {
+		return issues
+	}
+
+	var issue string
+
+	switch {
+	case apiObject.DataLocation != nil:
+		// The data location is registered before granting when register_data_location is set.
+		if !registerDataLocation {
+			resourceArn := aws.StringValue(apiObject.DataLocation.ResourceArn)
+			issue = lakeFormationGrantFeasibilityIssue(checks.dataLocation(resourceArn), "data location (%s) is not registered with Lake Formation", resourceArn)
+		}
+	case apiObject.Database != nil:
+		v := apiObject.Database
+		issue = lakeFormationGrantFeasibilityIssue(checks.database(aws.StringValue(v.CatalogId), aws.StringValue(v.Name)), "Glue database (%s) not found in catalog (%s)", aws.StringValue(v.Name), aws.StringValue(v.CatalogId))
+	case apiObject.Table != nil && tflakeformation.TableResourceIsWildcard(apiObject.Table):
+		v := apiObject.Table
+		issue = lakeFormationGrantFeasibilityIssue(checks.database(aws.StringValue(v.CatalogId), aws.StringValue(v.DatabaseName)), "Glue database (%s) not found in catalog (%s)", aws.StringValue(v.DatabaseName), aws.StringValue(v.CatalogId))
+	case apiObject.Table != nil:
+		v := apiObject.Table
+		issue = lakeFormationGrantFeasibilityIssue(checks.table(aws.StringValue(v.CatalogId), aws.StringValue(v.DatabaseName), aws.StringValue(v.Name)), "Glue table (%s.%s) not found in catalog (%s)", aws.StringValue(v.DatabaseName), aws.StringValue(v.Name), aws.StringValue(v.CatalogId))
+	case apiObject.TableWithColumns != nil:
+		v := apiObject.TableWithColumns
+		issue = lakeFormationGrantFeasibilityIssue(checks.table(aws.StringValue(v.CatalogId), aws.StringValue(v.DatabaseName), aws.StringValue(v.Name)), "Glue table (%s.%s) not found in catalog (%s)", aws.StringValue(v.DatabaseName), aws.StringValue(v.Name), aws.StringValue(v.CatalogId))
+	}
+
+	if issue != "" {
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+func lakeFormationGrantFeasibilityIssue(err error, format string, a ...interface{}) string {
+	if tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
+		return fmt.Sprintf(format, a...)
+	}
+
+	if err != nil {
+		log.Printf("[DEBUG] Skipping Lake Formation Permissions feasibility check: %s", err)
+	}
+
+	return ""
+}
+
+// lakeFormationIamPrincipalWarning returns a warning when lookup reports that the IAM role or user principal does
+// not exist. Other principals, and lookup errors other than NoSuchEntity, return no warning.
+func lakeFormationIamPrincipalWarning(principal string, lookup func(principalType, name string) error) string {
+	principalType, name := lakeFormationIamPrincipalName(principal)
+
+	if name == "" {
+		return ""
+	}
+
+	err := lookup(principalType, name)
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return fmt.Sprintf("Lake Formation Permissions principal (%s): IAM %s %s not found", principal, principalType, name)
+	}
+
+	if err != nil {
+		log.Printf("[DEBUG] Skipping Lake Formation Permissions principal (%s) check: %s", principal, err)
+	}
+
+	return ""
+}
+
+// lakeFormationIamPrincipalName returns the type ("role" or "user") and name of an IAM role or user principal ARN.
+// Both are empty for any other principal.
+func lakeFormationIamPrincipalName(principal string) (string, string) {
+	v, err := arn.Parse(principal)
+
+	if err != nil || v.Service != iam.ServiceName {
+		return "", ""
+	}
+
+	parts := strings.Split(v.Resource, "/")
+
+	if len(parts) < 2 || (parts[0] != "role" && parts[0] != "user") {
+		return "", ""
+	}
+
+	return parts[0], parts[len(parts)-1]
+}
+
+// resourceAwsLakeFormationPermissionsLogChanges logs a combined summary of the grants and revokes planned
+// for both permissions and permissions_with_grant_option.
+func resourceAwsLakeFormationPermissionsLogChanges(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || (!diff.HasChange("permissions") && !diff.HasChange("permissions_with_grant_option")) {
+		return nil
+	}
+
+	oldPermissions, newPermissions := diff.GetChange("permissions")
+	oldGrantPermissions, newGrantPermissions := diff.GetChange("permissions_with_grant_option")
+
+	log.Printf("[INFO] Lake Formation Permissions (%s) changes: permissions %s; permissions_with_grant_option %s", diff.Id(),
+		lakeFormationPermissionsChangeSummary(oldPermissions.(*schema.Set), newPermissions.(*schema.Set)),
+		lakeFormationPermissionsChangeSummary(oldGrantPermissions.(*schema.Set), newGrantPermissions.(*schema.Set)))
+
+	return nil
+}
+
+func resourceAwsLakeFormationPermissionsValidateResource(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return lakeFormationValidateResourceBlocks(diff.Get("catalog_resource").(bool), diff.Get("data_location").([]interface{}), diff.Get("database").([]interface{}), diff.Get("databases").(*schema.Set).List(), diff.Get("table").([]interface{}), diff.Get("table_with_columns").([]interface{}))
+}
+
+// lakeFormationValidateResourceBlocks returns an error unless exactly one resource is configured.
+func lakeFormationValidateResourceBlocks(catalogResource bool, dataLocation, database, databases, table, tableWithColumns []interface{}) error {
+	count := 0
+
+	if catalogResource {
+		count++
+	}
+
+	for _, v := range [][]interface{}{dataLocation, database, databases, table, tableWithColumns} {
+		if len(v) > 0 {
+			count++
+		}
+	}
+
+	if count != 1 {
+		return fmt.Errorf("exactly one of catalog_resource, data_location, database, databases, table, table_with_columns must be configured")
+	}
+
+	return nil
+}
+
+func resourceAwsLakeFormationPermissionsValidateCatalog(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	blockCatalogIds := make(map[string]string)
+
+	for _, key := range []string{"data_location", "database", "table", "table_with_columns"} {
+		if v, ok := diff.Get(key + ".0.catalog_id").(string); ok && v != "" {
+			blockCatalogIds[key] = v
+		}
+	}
+
+	return lakeFormationPermissionsCatalogConflict(diff.Get("catalog_resource").(bool), diff.Get("catalog_id").(string), blockCatalogIds)
+}
+
+// lakeFormationPermissionsCatalogConflict returns an error when a grant on the Data Catalog itself is combined
+// with a block-level catalog ID that names a different catalog than the resource-level one.
+func lakeFormationPermissionsCatalogConflict(catalogResource bool, catalogId string, blockCatalogIds map[string]string) error {
+	if !catalogResource {
+		return nil
+	}
+
+	keys := make([]string, 0, len(blockCatalogIds))
+	for k := range blockCatalogIds {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if v := blockCatalogIds[k]; v != catalogId {
+			if catalogId == "" {
+				return fmt.Errorf("catalog_resource is true but %s.0.catalog_id (%s) is set; use the top-level catalog_id to select the Data Catalog", k, v)
+			}
+
+			return fmt.Errorf("catalog_resource is true for catalog %s but %s.0.catalog_id is %s", catalogId, k, v)
+		}
+	}
+
+	return nil
 }
 
-func resourceAwsLakeFormationPermissionsCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lakeformationconn
+// lakeFormationMaxColumnsPerGrant is the maximum number of column names accepted for a single table with columns grant.
+const lakeFormationMaxColumnsPerGrant = 100
 
-	input := &lakeformation.GrantPermissionsInput{
-		Permissions: expandStringList(d.Get("permissions").([]interface{})),
-		Principal: &lakeformation.DataLakePrincipal{
-			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
-		},
+func resourceAwsLakeFormationPermissionsValidateColumnCount(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"table_with_columns.0.column_names", "table_with_columns.0.excluded_column_names"} {
+		if err := lakeFormationValidateColumnCount(key, len(diff.Get(key).([]interface{}))); err != nil {
+			return err
+		}
 	}
 
-	if v, ok := d.GetOk("catalog_id"); ok {
-		input.CatalogId = aws.String(v.(string))
+	return nil
+}
+
+func lakeFormationValidateColumnCount(key string, count int) error {
+	if count > lakeFormationMaxColumnsPerGrant {
+		return fmt.Errorf("%s: %d column names exceed the maximum of %d per grant; split the columns across several grants", key, count, lakeFormationMaxColumnsPerGrant)
 	}
 
-	if v, ok := d.GetOk("permissions_with_grant_option"); ok {
-		input.PermissionsWithGrantOption = expandStringList(v.([]interface{}))
+	return nil
+}
+
+// lakeFormationPermissionsChangeSummary returns a human-readable summary of the permissions granted and revoked
+// when moving from the old to the new set of permissions.
+func lakeFormationPermissionsChangeSummary(old, new *schema.Set) string {
+	grants := expandStringSet(new.Difference(old))
+	revokes := expandStringSet(old.Difference(new))
+
+	if len(grants) == 0 && len(revokes) == 0 {
+		return "unchanged"
 	}
 
-	input.Resource = expandLakeFormationResource(d, false)
+	granted := aws.StringValueSlice(grants)
+	sort.Strings(granted)
+	revoked := aws.StringValueSlice(revokes)
+	sort.Strings(revoked)
 
-	var output *lakeformation.GrantPermissionsOutput
-	err := resource.Retry(iamwaiter.PropagationTimeout, func() *resource.RetryError {
-		var err error
-		output, err = conn.GrantPermissions(input)
-		if err != nil {
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
-				return resource.RetryableError(err)
-			}
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Grantee has no permissions") {
-				return resource.RetryableError(err)
-			}
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "register the S3 path") {
-				return resource.RetryableError(err)
-			}
-			if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") {
-				return resource.RetryableError(err)
-			}
-			if isAWSErr(err, "AccessDeniedException", "is not authorized to access requested permissions") {
-				return resource.RetryableError(err)
-			}
+	return fmt.Sprintf("grant [%s], revoke [%s]", strings.Join(granted, ", "), strings.Join(revoked, ", "))
+}
 
-			return resource.NonRetryableError(fmt.Errorf("error creating Lake Formation Permissions: %w", err))
-		}
-		return nil
-	})
+// resourceAwsLakeFormationPermissionsCatalogIdDiff drops catalog ID changes between an unset value and the caller's
+// account ID, which Lake Formation treats as the same catalog.
+func resourceAwsLakeFormationPermissionsCatalogIdDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"catalog_id"}
 
-	if isResourceTimeoutError(err) {
-		output, err = conn.GrantPermissions(input)
+	for _, block := range []string{"data_location", "database", "table", "table_with_columns"} {
+		keys = append(keys, block+".0.catalog_id")
 	}
 
-	if err != nil {
-		return fmt.Errorf("error creating Lake Formation Permissions (input: %v): %w", input, err)
+	return lakeFormationClearEquivalentCatalogIds(diff, meta.(*AWSClient).accountid, keys)
+}
+
+// lakeFormationClearEquivalentCatalogIds clears the diff of each catalog ID key whose old and new values name the
+// same catalog. The keys must be computed.
+func lakeFormationClearEquivalentCatalogIds(diff *schema.ResourceDiff, accountId string, keys []string) error {
+	for _, key := range keys {
+		if !diff.HasChange(key) {
+			continue
+		}
+
+		o, n := diff.GetChange(key)
+
+		if !lakeFormationCatalogIdEquivalent(o.(string), n.(string), accountId) {
+			continue
+		}
+
+		if err := diff.Clear(key); err != nil {
+			return fmt.Errorf("error clearing %s diff: %w", key, err)
+		}
 	}
 
-	if output == nil {
-		return fmt.Errorf("error creating Lake Formation Permissions: empty response")
+	return nil
+}
+
+// lakeFormationCatalogIdEquivalent reports whether two catalog IDs name the same catalog, where an empty catalog ID
+// stands for the caller's account.
+func lakeFormationCatalogIdEquivalent(old, new, accountId string) bool {
+	if old == "" {
+		old = accountId
 	}
 
-	d.SetId(fmt.Sprintf("%d", hashcode.String(input.String())))
+	if new == "" {
+		new = accountId
+	}
 
-	return resourceAwsLakeFormationPermissionsRead(d, meta)
+	return old == new
 }
 
-func resourceAwsLakeFormationPermissionsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lakeformationconn
+const (
+	lakeFormationMultipleMatchesAggregate = "aggregate"
+	lakeFormationMultipleMatchesError     = "error"
+	lakeFormationMultipleMatchesWarn      = "warn"
+)
 
-	input := &lakeformation.ListPermissionsInput{
-		Principal: &lakeformation.DataLakePrincipal{
-			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
-		},
+func lakeFormationMultipleMatches_Values() []string {
+	return []string{
+		lakeFormationMultipleMatchesAggregate,
+		lakeFormationMultipleMatchesError,
+		lakeFormationMultipleMatchesWarn,
 	}
+}
 
-	if v, ok := d.GetOk("catalog_id"); ok {
-		input.CatalogId = aws.String(v.(string))
+// lakeFormationPermissionsResolveMultipleMatches handles matched entries describing more than one distinct
+// resource. The SELECT companion of a matched table is part of the same grant and is not counted. Any number of
+// entries for the same resource, such as overlapping grants made by different pipelines, is not a conflict as
+// their permissions are aggregated. Depending on mode, conflicting resources return an error, keep only the entries
+// of the first resource and its SELECT companion with a warning, or are kept so that all of their permissions are
+// aggregated.
+func lakeFormationPermissionsResolveMultipleMatches(id, mode string, apiObjects []*lakeformation.PrincipalResourcePermissions) ([]*lakeformation.PrincipalResourcePermissions, error) {
+	var grants []*lakeformation.PrincipalResourcePermissions
+	for _, apiObject := range apiObjects {
+		if lakeFormationPermissionsSelectCompanionOf(apiObject, apiObjects) == nil {
+			grants = append(grants, apiObject)
+		}
 	}
 
-	input.Resource = expandLakeFormationResource(d, true)
-	matchResource := expandLakeFormationResource(d, false)
-	// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
-	selectPermissionsResource := expandLakeFormationResourceForSelectPermissions(d)
+	resources := lakeFormationPermissionsDistinctResources(grants)
 
-	log.Printf("[DEBUG] Reading Lake Formation permissions: %v", input)
-	var principalResourcePermissions []*lakeformation.PrincipalResourcePermissions
+	if len(resources) <= 1 {
+		return apiObjects, nil
+	}
 
-	err := resource.Retry(iamwaiter.PropagationTimeout, func() *resource.RetryError {
-		err := conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
-			for _, permission := range resp.PrincipalResourcePermissions {
-				if permission == nil {
-					continue
-				}
-
-				if resourceAwsLakeFormationPermissionsCompareResource(*matchResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-					continue
-				}
-
-				// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
-				if selectPermissionsResource != nil && resourceAwsLakeFormationPermissionsCompareResource(*selectPermissionsResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-				}
+	switch mode {
+	case lakeFormationMultipleMatchesAggregate:
+		log.Printf("[DEBUG] Lake Formation permissions (%s) aggregating %d matching resources", id, len(resources))
+		return apiObjects, nil
+	case lakeFormationMultipleMatchesWarn:
+		log.Printf("[WARN] Lake Formation permissions (%s) found %d matching resources for same grant; using the first", id, len(resources))
+
+		var first []*lakeformation.PrincipalResourcePermissions
+		for _, apiObject := range apiObjects {
+			if apiObject.Resource.String() == resources[0] {
+				first = append(first, apiObject)
+				continue
 			}
-			return !lastPage
-		})
 
-		if err != nil {
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "Invalid principal") {
-				return resource.RetryableError(err)
+			if v := lakeFormationPermissionsSelectCompanionOf(apiObject, apiObjects); v != nil && v.String() == resources[0] {
+				first = append(first, apiObject)
 			}
-			return resource.NonRetryableError(fmt.Errorf("error creating Lake Formation Permissions: %w", err))
 		}
-		return nil
-	})
 
-	if isResourceTimeoutError(err) {
-		err = conn.ListPermissionsPages(input, func(resp *lakeformation.ListPermissionsOutput, lastPage bool) bool {
-			for _, permission := range resp.PrincipalResourcePermissions {
-				if permission == nil {
-					continue
-				}
-
-				if resourceAwsLakeFormationPermissionsCompareResource(*matchResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-					continue
-				}
-
-				// AWS treats SELECT permissions differently. A separate resource is created for the {db}.{table}.* to grant select on all columns
-				if selectPermissionsResource != nil && resourceAwsLakeFormationPermissionsCompareResource(*selectPermissionsResource, *permission.Resource) {
-					principalResourcePermissions = append(principalResourcePermissions, permission)
-				}
-			}
-			return !lastPage
-		})
+		return first, nil
 	}
 
-	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeEntityNotFoundException) {
-		log.Printf("[WARN] Resource Lake Formation permissions (%s) not found, removing from state", d.Id())
-		d.SetId("")
+	return nil, fmt.Errorf("multiple permissions found for same resource: %s", strings.Join(resources, ", "))
+}
+
+// lakeFormationPermissionsSelectCompanionOf returns the table resource of the entries for which apiObject is the
+// SELECT companion, i.e. the table with columns wildcard entry AWS creates alongside a SELECT grant on a table, or
+// nil if apiObject is not a companion.
+func lakeFormationPermissionsSelectCompanionOf(apiObject *lakeformation.PrincipalResourcePermissions, apiObjects []*lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+	if apiObject == nil || apiObject.Resource == nil || apiObject.Resource.TableWithColumns == nil {
 		return nil
 	}
 
-	if err != nil {
-		return fmt.Errorf("error reading Lake Formation permissions: %w", err)
+	for _, v := range apiObjects {
+		if v == nil || v.Resource == nil || v.Resource.Table == nil {
+			continue
+		}
+
+		in := lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:    v.Resource.Table.CatalogId,
+				DatabaseName: v.Resource.Table.DatabaseName,
+				Name:         v.Resource.Table.Name,
+			},
+		}
+
+		if tflakeformation.SelectResourceEqual(in, *apiObject.Resource) {
+			return v.Resource
+		}
 	}
 
-	if len(principalResourcePermissions) == 0 {
-		return fmt.Errorf("error reading Lake Formation permissions: %s", "no permissions found")
+	return nil
+}
+
+// lakeFormationPermissionsDistinctResources returns the distinct resources of the entries, in order.
+func lakeFormationPermissionsDistinctResources(apiObjects []*lakeformation.PrincipalResourcePermissions) []string {
+	var resources []string
+	seen := make(map[string]bool)
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil {
+			continue
+		}
+
+		if v := apiObject.Resource.String(); !seen[v] {
+			seen[v] = true
+			resources = append(resources, v)
+		}
 	}
 
-	if len(principalResourcePermissions) > 2 {
-		return fmt.Errorf("error reading Lake Formation permissions: %s", "multiple permissions found for same resource")
+	return resources
+}
+
+const (
+	lakeFormationCrossAccountStatusActive            = "ACTIVE"
+	lakeFormationCrossAccountStatusPendingAcceptance = "PENDING_ACCEPTANCE"
+)
+
+// resourceAwsLakeFormationPermissionsCrossAccountStatus returns the status of grants shared through AWS RAM.
+// A grant whose resource share has not yet been accepted by the recipient is still returned by Lake Formation
+// and is reported as pending rather than as drift. An empty status is returned for grants that are not shared
+// or whose status cannot be determined.
+func resourceAwsLakeFormationPermissionsCrossAccountStatus(conn *ram.RAM, apiObjects []*lakeformation.PrincipalResourcePermissions) string {
+	var associations []*ram.ResourceShareAssociation
+
+	for _, apiObject := range apiObjects {
+		if !tflakeformation.PermissionsIsResourceShared(apiObject) || apiObject.Principal == nil {
+			continue
+		}
+
+		for _, resourceShareArn := range apiObject.AdditionalDetails.ResourceShare {
+			association, err := ramfinder.ResourceSharePrincipalAssociationByShareARNPrincipal(conn, aws.StringValue(resourceShareArn), aws.StringValue(apiObject.Principal.DataLakePrincipalIdentifier))
+
+			if err != nil {
+				log.Printf("[DEBUG] Unable to read RAM resource share (%s) association: %s", aws.StringValue(resourceShareArn), err)
+				return ""
+			}
+
+			if association != nil {
+				associations = append(associations, association)
+			}
+		}
 	}
 
-	d.Set("principal", principalResourcePermissions[0].Principal.DataLakePrincipalIdentifier)
-	d.Set("permissions", flattenLakeFormationPermissions(principalResourcePermissions))
-	d.Set("permissions_with_grant_option", flattenLakeFormationGrantPermissions(principalResourcePermissions))
+	return lakeFormationPermissionsCrossAccountStatus(associations)
+}
 
-	if principalResourcePermissions[0].Resource.Catalog != nil {
-		d.Set("catalog_resource", true)
+// lakeFormationPermissionsAnyResourceShared reports whether any of the entries was granted through AWS RAM, in
+// which case the status of its resource share association is read.
+func lakeFormationPermissionsAnyResourceShared(apiObjects []*lakeformation.PrincipalResourcePermissions) bool {
+	for _, apiObject := range apiObjects {
+		if tflakeformation.PermissionsIsResourceShared(apiObject) {
+			return true
+		}
 	}
 
-	if principalResourcePermissions[0].Resource.DataLocation != nil {
-		d.Set("data_location", []interface{}{flattenLakeFormationDataLocationResource(principalResourcePermissions[0].Resource.DataLocation)})
-	} else {
-		d.Set("data_location", nil)
+	return false
+}
+
+func lakeFormationPermissionsCrossAccountStatus(associations []*ram.ResourceShareAssociation) string {
+	if len(associations) == 0 {
+		return ""
 	}
 
-	if principalResourcePermissions[0].Resource.Database != nil {
-		d.Set("database", []interface{}{flattenLakeFormationDatabaseResource(principalResourcePermissions[0].Resource.Database)})
-	} else {
-		d.Set("database", nil)
+	for _, association := range associations {
+		if aws.StringValue(association.Status) == ram.ResourceShareAssociationStatusAssociating {
+			return lakeFormationCrossAccountStatusPendingAcceptance
+		}
 	}
 
-	// table with columns permissions will include the table and table with columns
-	if principalResourcePermissions[0].Resource.TableWithColumns != nil {
-		d.Set("table_with_columns", []interface{}{flattenLakeFormationTableWithColumnsResource(principalResourcePermissions[0].Resource.TableWithColumns)})
-	} else if principalResourcePermissions[0].Resource.Table != nil {
-		d.Set("table_with_columns", nil)
-		d.Set("table", []interface{}{flattenLakeFormationTableResource(principalResourcePermissions[0].Resource.Table)})
-	} else {
-		d.Set("table", nil)
+	return lakeFormationCrossAccountStatusActive
+}
+
+// lakeFormationResourceWithConfigColumnCase returns a copy of a table with columns resource with the column names
+// spelled as in matchResource wherever they only differ by case, for catalogs that lowercase column names.
+func lakeFormationResourceWithConfigColumnCase(matchResource, apiObject *lakeformation.Resource) *lakeformation.Resource {
+	if matchResource == nil || matchResource.TableWithColumns == nil || apiObject == nil || apiObject.TableWithColumns == nil {
+		return apiObject
 	}
-	return nil
+
+	in, out := matchResource.TableWithColumns, *apiObject.TableWithColumns
+	out.ColumnNames = lakeFormationColumnNamesWithConfigCase(in.ColumnNames, out.ColumnNames)
+
+	if in.ColumnWildcard != nil && out.ColumnWildcard != nil {
+		v := *out.ColumnWildcard
+		v.ExcludedColumnNames = lakeFormationColumnNamesWithConfigCase(in.ColumnWildcard.ExcludedColumnNames, v.ExcludedColumnNames)
+		out.ColumnWildcard = &v
+	}
+
+	v := *apiObject
+	v.TableWithColumns = &out
+
+	return &v
 }
 
-func resourceAwsLakeFormationPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).lakeformationconn
+// lakeFormationResourceWithConfigTableNameCase returns a copy of a table or table with columns resource with the
+// table name spelled as in matchResource when they only differ by case, for catalogs that lowercase table names.
+func lakeFormationResourceWithConfigTableNameCase(matchResource, apiObject *lakeformation.Resource) *lakeformation.Resource {
+	if matchResource == nil || apiObject == nil {
+		return apiObject
+	}
 
-	input := &lakeformation.RevokePermissionsInput{
-		Permissions: expandStringList(d.Get("permissions").([]interface{})),
-		Principal: &lakeformation.DataLakePrincipal{
-			DataLakePrincipalIdentifier: aws.String(d.Get("principal").(string)),
-		},
+	var name *string
+	switch {
+	case matchResource.Table != nil:
+		name = matchResource.Table.Name
+	case matchResource.TableWithColumns != nil:
+		name = matchResource.TableWithColumns.Name
 	}
 
-	if v, ok := d.GetOk("catalog_id"); ok {
-		input.CatalogId = aws.String(v.(string))
+	if name == nil {
+		return apiObject
 	}
 
-	if v, ok := d.GetOk("permissions_with_grant_option"); ok {
-		input.PermissionsWithGrantOption = expandStringList(v.([]interface{}))
+	out := *apiObject
+
+	if v := out.Table; v != nil && v.Name != nil && strings.EqualFold(aws.StringValue(v.Name), aws.StringValue(name)) {
+		table := *v
+		table.Name = aws.String(aws.StringValue(name))
+		out.Table = &table
 	}
 
-	input.Resource = expandLakeFormationResource(d, false)
+	if v := out.TableWithColumns; v != nil && v.Name != nil && strings.EqualFold(aws.StringValue(v.Name), aws.StringValue(name)) {
+		table := *v
+		table.Name = aws.String(aws.StringValue(name))
+		out.TableWithColumns = &table
+	}
 
-	err := resource.Retry(2*time.Minute, func() *resource.RetryError {
-		var err error
-		_, err = conn.RevokePermissions(input)
-		if err != nil {
-			if isAWSErr(err, lakeformation.ErrCodeInvalidInputException, "register the S3 path") {
-				return resource.RetryableError(err)
-			}
-			if isAWSErr(err, lakeformation.ErrCodeConcurrentModificationException, "") {
-				return resource.RetryableError(err)
-			}
+	return &out
+}
+
+func lakeFormationColumnNamesWithConfigCase(in, out []*string) []*string {
+	if len(in) == 0 || len(out) == 0 {
+		return out
+	}
+
+	spellings := make(map[string]string, len(in))
+	for _, v := range in {
+		spellings[strings.ToLower(aws.StringValue(v))] = aws.StringValue(v)
+	}
 
-			return resource.NonRetryableError(fmt.Errorf("unable to revoke Lake Formation Permissions: %w", err))
+	columnNames := make([]*string, 0, len(out))
+	for _, v := range out {
+		if spelling, ok := spellings[strings.ToLower(aws.StringValue(v))]; ok {
+			v = aws.String(spelling)
 		}
-		return nil
-	})
+		columnNames = append(columnNames, v)
+	}
 
-	if isResourceTimeoutError(err) {
-		_, err = conn.RevokePermissions(input)
+	return columnNames
+}
+
+// flattenLakeFormationPermissionsTableBlocks returns the table and table_with_columns blocks for the matched entries.
+// A table SELECT grant is also reported as a companion table with columns entry, so at most one of the blocks
+// is ever populated: the table block for a table configuration and the table_with_columns block otherwise.
+func flattenLakeFormationPermissionsTableBlocks(resourceType string, apiObjects []*lakeformation.PrincipalResourcePermissions) ([]interface{}, []interface{}) {
+	if resourceType == lakeformation.DataLakeResourceTypeTable {
+		// a table configuration is always reflected in the table block, even if only the SELECT companion matched
+		if v := resourceAwsLakeFormationPermissionsTableResource(apiObjects); v != nil {
+			return []interface{}{flattenLakeFormationTableResource(v)}, nil
+		}
+
+		return nil, nil
 	}
 
-	if err != nil {
-		return fmt.Errorf("unable to revoke LakeFormation Permissions (input: %v): %w", input, err)
+	if resourceType == DataLakeResourceTypeTableWithColumns {
+		// a table with columns configuration is never reflected in the table block, even if AWS also returned
+		// the companion table entry for the same table
+		for _, apiObject := range apiObjects {
+			if apiObject != nil && apiObject.Resource != nil && apiObject.Resource.TableWithColumns != nil {
+				return nil, []interface{}{flattenLakeFormationTableWithColumnsResource(apiObject.Resource.TableWithColumns)}
+			}
+		}
+
+		return nil, nil
 	}
 
-	return nil
+	if len(apiObjects) == 0 || apiObjects[0] == nil || apiObjects[0].Resource == nil {
+		return nil, nil
+	}
+
+	if v := apiObjects[0].Resource.TableWithColumns; v != nil {
+		return nil, []interface{}{flattenLakeFormationTableWithColumnsResource(v)}
+	}
+
+	if v := apiObjects[0].Resource.Table; v != nil {
+		return []interface{}{flattenLakeFormationTableResource(v)}, nil
+	}
+
+	return nil, nil
 }
 
-func resourceAwsLakeFormationPermissionsCompareResource(in, out lakeformation.Resource) bool {
-	if in.DataLocation != nil && out.DataLocation != nil && in.DataLocation.CatalogId == nil {
-		in.DataLocation.CatalogId = out.DataLocation.CatalogId
+// lakeFormationPermissionsHasCompanionSelect reports whether the matched entries of a table configuration include
+// the table with columns entry AWS creates for a SELECT grant on the table. A companion entry left with only grant
+// options, e.g. after SELECT was revoked outside of Terraform, is not an active grant.
+func lakeFormationPermissionsHasCompanionSelect(resourceType string, apiObjects []*lakeformation.PrincipalResourcePermissions) bool {
+	if resourceType != lakeformation.DataLakeResourceTypeTable {
+		return false
 	}
 
-	if in.Database != nil && out.Database != nil && in.Database.CatalogId == nil {
-		in.Database.CatalogId = out.Database.CatalogId
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil || lakeFormationPermissionsIsGrantableOnly(apiObject) {
+			continue
+		}
+
+		if v := apiObject.Resource.TableWithColumns; v != nil && v.ColumnWildcard != nil {
+			return true
+		}
 	}
 
-	if in.Table != nil && out.Table != nil && in.Table.CatalogId == nil {
-		in.Table.CatalogId = out.Table.CatalogId
+	return false
+}
+
+// lakeFormationPermissionsIsGrantableOnly reports whether ListPermissions returned the entry only for permissions
+// the principal can grant, without holding any of them.
+func lakeFormationPermissionsIsGrantableOnly(apiObject *lakeformation.PrincipalResourcePermissions) bool {
+	return apiObject != nil && len(apiObject.Permissions) == 0 && len(apiObject.PermissionsWithGrantOption) > 0
+}
+
+// resourceAwsLakeFormationPermissionsTableResource returns the table resource described by the matched entries.
+// A SELECT grant on a table is also reported as a table with columns entry, which can be the only entry returned.
+func resourceAwsLakeFormationPermissionsTableResource(apiObjects []*lakeformation.PrincipalResourcePermissions) *lakeformation.TableResource {
+	var companion *lakeformation.TableWithColumnsResource
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.Resource == nil {
+			continue
+		}
+
+		if apiObject.Resource.Table != nil {
+			return apiObject.Resource.Table
+		}
+
+		if companion == nil && apiObject.Resource.TableWithColumns != nil {
+			companion = apiObject.Resource.TableWithColumns
+		}
 	}
 
-	if in.TableWithColumns != nil && out.TableWithColumns != nil && in.TableWithColumns.CatalogId == nil {
-		in.TableWithColumns.CatalogId = out.TableWithColumns.CatalogId
+	if companion == nil {
+		return nil
 	}
 
-	return reflect.DeepEqual(in, out)
+	return &lakeformation.TableResource{
+		CatalogId:    companion.CatalogId,
+		DatabaseName: companion.DatabaseName,
+		Name:         companion.Name,
+	}
 }
 
 // expandLakeFormationResourceType returns the Lake Formation resource type represented by the resource.
@@ -453,7 +2699,12 @@ func expandLakeFormationResourceType(d *schema.ResourceData) string {
 	return DataLakeResourceTypeTableWithColumns
 }
 
-const DataLakeResourceTypeTableWithColumns = "TABLE_WITH_COLUMNS" // no lakeformation package enum value for this type
+const DataLakeResourceTypeTableWithColumns = tflakeformation.DataLakeResourceTypeTableWithColumns
+
+// lakeFormationResourceTypeOf returns the Lake Formation resource type represented by an API resource.
+func lakeFormationResourceTypeOf(apiObject *lakeformation.Resource) string {
+	return tflakeformation.ResourceType(apiObject)
+}
 
 func expandLakeFormationResource(d *schema.ResourceData, squashTableWithColumns bool) *lakeformation.Resource {
 	res := &lakeformation.Resource{}
@@ -462,24 +2713,33 @@ func expandLakeFormationResource(d *schema.ResourceData, squashTableWithColumns
 	case lakeformation.DataLakeResourceTypeCatalog:
 		res.Catalog = &lakeformation.CatalogResource{}
 	case lakeformation.DataLakeResourceTypeDataLocation:
-		res.DataLocation = expandLakeFormationDataLocationResource(d.Get("data_location").([]interface{})[0].(map[string]interface{}))
+		res.DataLocation = expandLakeFormationDataLocationResource(lakeFormationResourceBlock(d, "data_location"))
 	case lakeformation.DataLakeResourceTypeDatabase:
-		res.Database = expandLakeFormationDatabaseResource(d.Get("database").([]interface{})[0].(map[string]interface{}))
+		res.Database = expandLakeFormationDatabaseResource(lakeFormationResourceBlock(d, "database"))
 	case lakeformation.DataLakeResourceTypeTable:
-		res.Table = expandLakeFormationTableResource(d.Get("table").([]interface{})[0].(map[string]interface{}))
+		res.Table = expandLakeFormationTableResource(lakeFormationResourceBlock(d, "table"))
 	case DataLakeResourceTypeTableWithColumns:
 		if squashTableWithColumns {
 			// ListPermissions does not support getting privileges by tables with columns. Instead,
 			// use the table which will return both table and table with columns.
-			res.Table = expandLakeFormationTableResource(d.Get("table_with_columns").([]interface{})[0].(map[string]interface{}))
+			res.Table = expandLakeFormationTableResource(lakeFormationResourceBlock(d, "table_with_columns"))
 		} else {
-			res.TableWithColumns = expandLakeFormationTableWithColumnsResource(d.Get("table_with_columns").([]interface{})[0].(map[string]interface{}))
+			res.TableWithColumns = expandLakeFormationTableWithColumnsResource(lakeFormationResourceBlock(d, "table_with_columns"))
 		}
 	}
 
 	return res
 }
 
+// lakeFormationResourceBlock returns the configured resource block, or nil when the block is not configured.
+func lakeFormationResourceBlock(d *schema.ResourceData, key string) map[string]interface{} {
+	if v, ok := d.Get(key).([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		return v[0].(map[string]interface{})
+	}
+
+	return nil
+}
+
 func expandLakeFormationResourceForSelectPermissions(d *schema.ResourceData) *lakeformation.Resource {
 	tableMapSchema := d.Get("table").([]interface{})
 	if len(tableMapSchema) == 0 {
@@ -496,7 +2756,8 @@ func expandLakeFormationResourceForSelectPermissions(d *schema.ResourceData) *la
 		return nil
 	}
 	name, ok := tableSchema["name"].(string)
-	if !ok {
+	if !ok || name == "" {
+		// A wildcard table grant has no companion table with columns resource.
 		return nil
 	}
 
@@ -624,12 +2885,11 @@ func flattenLakeFormationTableResource(apiObject *lakeformation.TableResource) m
 		tfMap["database_name"] = aws.StringValue(v)
 	}
 
-	if v := apiObject.Name; v != nil {
-		tfMap["name"] = aws.StringValue(v)
-	}
-
-	if v := apiObject.TableWildcard; v != nil {
+	if tflakeformation.TableResourceIsWildcard(apiObject) {
+		// Any name returned alongside a wildcard is a placeholder, not a table.
 		tfMap["wildcard"] = true
+	} else if v := apiObject.Name; v != nil {
+		tfMap["name"] = aws.StringValue(v)
 	}
 
 	return tfMap
@@ -654,6 +2914,11 @@ func expandLakeFormationTableWithColumnsResource(tfMap map[string]interface{}) *
 		apiObject.DatabaseName = aws.String(v)
 	}
 
+	// A column wildcard without excluded columns covers every column of the table.
+	if v, ok := tfMap["wildcard"].(bool); ok && v {
+		apiObject.ColumnWildcard = &lakeformation.ColumnWildcard{}
+	}
+
 	if v, ok := tfMap["excluded_column_names"]; ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
 		apiObject.ColumnWildcard = &lakeformation.ColumnWildcard{
 			ExcludedColumnNames: expandStringList(v.([]interface{})),
@@ -672,6 +2937,8 @@ func flattenLakeFormationTableWithColumnsResource(apiObject *lakeformation.Table
 		return nil
 	}
 
+	apiObject = tflakeformation.TableWithColumnsResourceForConfig(nil, apiObject)
+
 	tfMap := map[string]interface{}{}
 
 	if v := apiObject.CatalogId; v != nil {
@@ -692,6 +2959,9 @@ func flattenLakeFormationTableWithColumnsResource(apiObject *lakeformation.Table
 		tfMap["name"] = aws.StringValue(v)
 	}
 
+	// Distinguishes a column wildcard without excluded columns, i.e. every column, from no column wildcard at all.
+	tfMap["wildcard"] = apiObject.ColumnWildcard != nil && len(apiObject.ColumnWildcard.ExcludedColumnNames) == 0
+
 	return tfMap
 }
 
@@ -700,15 +2970,17 @@ func flattenLakeFormationPermissions(apiObjects []*lakeformation.PrincipalResour
 		return nil
 	}
 
-	tfList := make([]string, 0)
+	// A permission can be listed in both Permissions and PermissionsWithGrantOption of an entry, and in several
+	// matched entries. Only Permissions is used here, and each value is reported once.
+	permissions := make([]string, 0)
 
 	for _, resourcePermission := range apiObjects {
-		for _, permission := range resourcePermission.Permissions {
-			tfList = append(tfList, aws.StringValue(permission))
+		for _, v := range aws.StringValueSlice(resourcePermission.Permissions) {
+			permissions = appendUniqueString(permissions, v)
 		}
 	}
 
-	return tfList
+	return permissions
 }
 
 func flattenLakeFormationGrantPermissions(apiObjects []*lakeformation.PrincipalResourcePermissions) []string {
@@ -716,13 +2988,110 @@ func flattenLakeFormationGrantPermissions(apiObjects []*lakeformation.PrincipalR
 		return nil
 	}
 
-	tfList := make([]string, 0)
+	permissions := make([]string, 0)
 
 	for _, resourcePermission := range apiObjects {
-		for _, grantPermission := range resourcePermission.PermissionsWithGrantOption {
-			tfList = append(tfList, aws.StringValue(grantPermission))
+		for _, v := range aws.StringValueSlice(resourcePermission.PermissionsWithGrantOption) {
+			permissions = appendUniqueString(permissions, v)
+		}
+	}
+
+	return permissions
+}
+
+// flattenLakeFormationPermissionsDiff returns the permissions reported by AWS but not in state (extra_in_aws) and
+// the permissions in state but not reported by AWS (missing_in_aws), both sorted.
+func flattenLakeFormationPermissionsDiff(expected, reported []string) map[string]interface{} {
+	extra := lakeFormationStringsDifference(reported, expected)
+	missing := lakeFormationStringsDifference(expected, reported)
+
+	return map[string]interface{}{
+		"extra_in_aws":   flattenStringList(aws.StringSlice(extra)),
+		"missing_in_aws": flattenStringList(aws.StringSlice(missing)),
+	}
+}
+
+// lakeFormationStringsDifference returns the sorted values of a that are not in b.
+func lakeFormationStringsDifference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+
+	difference := make([]string, 0)
+	for _, v := range a {
+		if !in[v] {
+			difference = append(difference, v)
+			in[v] = true
+		}
+	}
+
+	sort.Strings(difference)
+
+	return difference
+}
+
+// lakeFormationBroadPermissions are the permissions that imply every other permission on a resource.
+// Any admin-equivalent permission added by AWS in the future belongs here.
+var lakeFormationBroadPermissions = []string{
+	lakeformation.PermissionAll,
+}
+
+// lakeFormationCollapseBroadPermissions drops the permissions implied by a broad permission, unless they are
+// configured explicitly, so that AWS reporting both the broad permission and the permissions it implies, e.g.
+// from the SELECT companion entry of a table, does not cause a difference.
+func lakeFormationCollapseBroadPermissions(permissions []string, configured *schema.Set) []string {
+	var broad bool
+
+	for _, v := range permissions {
+		for _, b := range lakeFormationBroadPermissions {
+			if v == b {
+				broad = true
+			}
+		}
+	}
+
+	if !broad {
+		return permissions
+	}
+
+	tfList := make([]string, 0, len(permissions))
+
+	for _, v := range permissions {
+		implied := true
+
+		for _, b := range lakeFormationBroadPermissions {
+			if v == b {
+				implied = false
+			}
+		}
+
+		if implied && (configured == nil || !configured.Contains(v)) {
+			continue
 		}
+
+		tfList = append(tfList, v)
 	}
 
 	return tfList
 }
+
+// flattenLakeFormationNormalizedPermissions returns the sorted, de-duplicated permissions exactly as AWS stores
+// them across all matched entries. Collapsed permissions such as ALL are not expanded.
+func flattenLakeFormationNormalizedPermissions(apiObjects []*lakeformation.PrincipalResourcePermissions) []string {
+	if apiObjects == nil {
+		return nil
+	}
+
+	permissions := make([]string, 0)
+
+	for _, resourcePermission := range apiObjects {
+		for _, v := range aws.StringValueSlice(resourcePermission.Permissions) {
+			permissions = appendUniqueString(permissions, v)
+		}
+	}
+
+	sort.Strings(permissions)
+
+	return permissions
+}
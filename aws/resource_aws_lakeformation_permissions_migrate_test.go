@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func testResourceAwsLakeFormationPermissionsStateDataV0() map[string]interface{} {
+	return map[string]interface{}{
+		"id":                            "1234567890",
+		"catalog_id":                    "",
+		"catalog_resource":              false,
+		"permissions":                   []interface{}{"ALTER", "CREATE_TABLE", "DROP", "CREATE_TABLE"},
+		"permissions_with_grant_option": []interface{}{"CREATE_TABLE"},
+		"principal":                     "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT005
+		"database": []interface{}{
+			map[string]interface{}{
+				"catalog_id": "123456789012",
+				"name":       "test",
+			},
+		},
+	}
+}
+
+func testResourceAwsLakeFormationPermissionsStateDataV1() map[string]interface{} {
+	v0 := testResourceAwsLakeFormationPermissionsStateDataV0()
+	return map[string]interface{}{
+		"id":                            "arn:aws:iam::123456789012:role/test,DATABASE,123456789012,test", //lintignore:AWSAT005
+		"catalog_id":                    v0["catalog_id"],
+		"catalog_resource":              v0["catalog_resource"],
+		"permissions":                   []interface{}{"ALTER", "CREATE_TABLE", "DROP"},
+		"permissions_with_grant_option": []interface{}{"CREATE_TABLE"},
+		"principal":                     v0["principal"],
+		"database":                      v0["database"],
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsStateUpgradeV0(t *testing.T) {
+	expected := testResourceAwsLakeFormationPermissionsStateDataV1()
+	actual, err := resourceAwsLakeFormationPermissionsStateUpgradeV0(context.Background(), testResourceAwsLakeFormationPermissionsStateDataV0(), &AWSClient{accountid: "123456789012"})
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsStateUpgradeV0ID(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		State    map[string]interface{}
+		Expected string
+	}{
+		{
+			Name:     "database",
+			State:    testResourceAwsLakeFormationPermissionsStateDataV0(),
+			Expected: "arn:aws:iam::123456789012:role/test,DATABASE,123456789012,test", //lintignore:AWSAT005
+		},
+		{
+			Name: "catalog",
+			State: map[string]interface{}{
+				"id":               "42",
+				"catalog_resource": true,
+				"principal":        "123456789012",
+			},
+			Expected: "123456789012,CATALOG,123456789012",
+		},
+		{
+			Name: "wildcard table",
+			State: map[string]interface{}{
+				"id":        "42",
+				"principal": "123456789012",
+				"table": []interface{}{
+					map[string]interface{}{
+						"catalog_id":    "123456789012",
+						"database_name": "db",
+						"name":          "",
+						"wildcard":      true,
+					},
+				},
+			},
+			Expected: "123456789012,TABLE,123456789012,db,*",
+		},
+		{
+			Name: "already upgraded",
+			State: map[string]interface{}{
+				"id":        "123456789012,DATABASE,test,42",
+				"principal": "123456789012",
+			},
+			Expected: "123456789012,DATABASE,test,42",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			actual, err := resourceAwsLakeFormationPermissionsStateUpgradeV0(context.Background(), testCase.State, &AWSClient{accountid: "123456789012"})
+			if err != nil {
+				t.Fatalf("error migrating state: %s", err)
+			}
+
+			if got := actual["id"]; got != testCase.Expected {
+				t.Errorf("expected ID %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -1,17 +1,3966 @@
 package aws
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/lakeformation"
+	"github.com/aws/aws-sdk-go/service/ram"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	tflakeformation "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation"
 )
 
+func TestIsLakeFormationRetryableErrors(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Err    error
+		Any    bool
+		Grant  bool
+		Revoke bool
+		List   bool
+	}{
+		{
+			Name: "no error",
+		},
+		{
+			Name:   "throttling",
+			Err:    awserr.New(lakeFormationErrCodeThrottlingException, "Rate exceeded", nil),
+			Any:    true,
+			Grant:  true,
+			Revoke: true,
+			List:   true,
+		},
+		{
+			Name:   "operation timeout",
+			Err:    awserr.New(lakeformation.ErrCodeOperationTimeoutException, "Operation timed out", nil),
+			Any:    true,
+			Grant:  true,
+			Revoke: true,
+			List:   true,
+		},
+		{
+			Name:   "concurrent modification",
+			Err:    awserr.New(lakeformation.ErrCodeConcurrentModificationException, "Concurrent modification", nil),
+			Grant:  true,
+			Revoke: true,
+		},
+		{
+			Name:  "invalid principal",
+			Err:   awserr.New(lakeformation.ErrCodeInvalidInputException, "Invalid principal", nil),
+			Grant: true,
+			List:  true,
+		},
+		{
+			Name:  "resource does not exist",
+			Err:   awserr.New(lakeformation.ErrCodeInvalidInputException, "Resource does not exist or requester is not authorized to access requested permissions.", nil),
+			Grant: true,
+		},
+		{
+			Name:   "unregistered S3 path",
+			Err:    awserr.New(lakeformation.ErrCodeInvalidInputException, "Please register the S3 path first", nil),
+			Grant:  true,
+			Revoke: true,
+		},
+		{
+			Name: "other invalid input",
+			Err:  awserr.New(lakeformation.ErrCodeInvalidInputException, "Permissions modification is invalid.", nil),
+		},
+		{
+			Name: "entity not found",
+			Err:  awserr.New(lakeformation.ErrCodeEntityNotFoundException, "Resource does not exist", nil),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := isLakeFormationRetryableError(testCase.Err); got != testCase.Any {
+				t.Errorf("isLakeFormationRetryableError: expected %t, got %t", testCase.Any, got)
+			}
+
+			if got := isLakeFormationGrantPermissionsRetryableError(testCase.Err); got != testCase.Grant {
+				t.Errorf("isLakeFormationGrantPermissionsRetryableError: expected %t, got %t", testCase.Grant, got)
+			}
+
+			if got := isLakeFormationRevokePermissionsRetryableError(testCase.Err); got != testCase.Revoke {
+				t.Errorf("isLakeFormationRevokePermissionsRetryableError: expected %t, got %t", testCase.Revoke, got)
+			}
+
+			if got := isLakeFormationListPermissionsRetryableError(testCase.Err); got != testCase.List {
+				t.Errorf("isLakeFormationListPermissionsRetryableError: expected %t, got %t", testCase.List, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationRetryFunc(t *testing.T) {
+	throttled := awserr.New(lakeFormationErrCodeThrottlingException, "Rate exceeded", nil)
+	timedOut := awserr.New(lakeformation.ErrCodeOperationTimeoutException, "Operation timed out", nil)
+	notExist := awserr.New(lakeformation.ErrCodeInvalidInputException, "Resource does not exist or requester is not authorized to access requested permissions.", nil)
+	invalid := awserr.New(lakeformation.ErrCodeInvalidInputException, "Permissions modification is invalid.", nil)
+
+	testCases := []struct {
+		Name          string
+		Retryable     func(error) bool
+		Errors        []error
+		ExpectedCalls int
+		ExpectedErr   error
+	}{
+		{
+			Name:          "grant throttled then success",
+			Retryable:     isLakeFormationGrantPermissionsRetryableError,
+			Errors:        []error{throttled},
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "revoke timed out then success",
+			Retryable:     isLakeFormationRevokePermissionsRetryableError,
+			Errors:        []error{timedOut},
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "list throttled and timed out then success",
+			Retryable:     isLakeFormationListPermissionsRetryableError,
+			Errors:        []error{throttled, timedOut},
+			ExpectedCalls: 3,
+		},
+		{
+			// Granting right after the database or table is created.
+			Name:          "grant on a new resource",
+			Retryable:     isLakeFormationGrantPermissionsRetryableError,
+			Errors:        []error{notExist, notExist, notExist},
+			ExpectedCalls: 4,
+		},
+		{
+			Name:          "list on a new resource",
+			Retryable:     isLakeFormationListPermissionsRetryableError,
+			Errors:        []error{notExist},
+			ExpectedCalls: 1,
+			ExpectedErr:   notExist,
+		},
+		{
+			Name:          "grant invalid input",
+			Retryable:     isLakeFormationGrantPermissionsRetryableError,
+			Errors:        []error{throttled, invalid},
+			ExpectedCalls: 2,
+			ExpectedErr:   invalid,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var calls int
+
+			err := lakeFormationRetry(context.Background(), 1*time.Minute, 10*time.Millisecond, lakeFormationRetryFunc(testCase.Retryable, func() error {
+				calls++
+
+				if calls <= len(testCase.Errors) {
+					return testCase.Errors[calls-1]
+				}
+
+				return nil
+			}))
+
+			if err != testCase.ExpectedErr {
+				t.Errorf("expected error %v, got %v", testCase.ExpectedErr, err)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("expected %d calls, got %d", testCase.ExpectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_dataLocationDescribe(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		DataLocation: &lakeformation.DataLocationResource{
+			ResourceArn: aws.String("arn:aws:s3:::bucket/data"), //lintignore:AWSAT005
+		},
+	}
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				DataLocation: &lakeformation.DataLocationResource{
+					ResourceArn: aws.String("arn:aws:s3:::bucket/data"), //lintignore:AWSAT005
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDataLocationAccess}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				DataLocation: &lakeformation.DataLocationResource{
+					CatalogId:   aws.String("123456789012"),
+					ResourceArn: aws.String("arn:aws:s3:::bucket/data/child"), //lintignore:AWSAT005
+				},
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	matches, err := lakeFormationPermissionsResolveMultipleMatches("test", lakeFormationMultipleMatchesError, tflakeformation.PermissionsAggregate(collector.matches))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("expected the DESCRIBE entry to match, got %v", matches)
+	}
+
+	configured := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionDescribe})
+	permissions := lakeFormationCollapseBroadPermissions(flattenLakeFormationPermissions(matches), configured)
+
+	if expected := []string{lakeformation.PermissionDescribe}; !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, permissions)
+	}
+
+	diff := flattenLakeFormationPermissionsDiff(aws.StringValueSlice(expandStringSet(configured)), permissions)
+
+	if len(diff["extra_in_aws"].([]interface{})) != 0 || len(diff["missing_in_aws"].([]interface{})) != 0 {
+		t.Errorf("expected no permissions difference, got %v", diff)
+	}
+
+	expected := map[string]interface{}{
+		"arn":        "arn:aws:s3:::bucket/data", //lintignore:AWSAT005
+		"catalog_id": "123456789012",
+	}
+
+	if got := flattenLakeFormationDataLocationResource(matches[0].Resource.DataLocation); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected data location %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_dataLocationBroadRegistration(t *testing.T) {
+	// The bucket is registered with Lake Formation as a whole and access is granted on both the registered location
+	// and a prefix under it.
+	entries := func() []*lakeformation.PrincipalResourcePermissions {
+		dataLocation := func(resourceArn string) *lakeformation.PrincipalResourcePermissions {
+			return &lakeformation.PrincipalResourcePermissions{
+				Permissions: aws.StringSlice([]string{lakeformation.PermissionDataLocationAccess}),
+				Principal: &lakeformation.DataLakePrincipal{
+					DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+				},
+				Resource: &lakeformation.Resource{
+					DataLocation: &lakeformation.DataLocationResource{
+						CatalogId:   aws.String("123456789012"),
+						ResourceArn: aws.String(resourceArn),
+					},
+				},
+			}
+		}
+
+		return []*lakeformation.PrincipalResourcePermissions{
+			dataLocation("arn:aws:s3:::bucket"),               //lintignore:AWSAT005
+			dataLocation("arn:aws:s3:::bucket/data/specific"), //lintignore:AWSAT005
+		}
+	}
+
+	testCases := []struct {
+		Name        string
+		ResourceArn string
+		Expected    []string
+	}{
+		{
+			Name:        "registered location",
+			ResourceArn: "arn:aws:s3:::bucket",           //lintignore:AWSAT005
+			Expected:    []string{"arn:aws:s3:::bucket"}, //lintignore:AWSAT005
+		},
+		{
+			Name:        "granted prefix",
+			ResourceArn: "arn:aws:s3:::bucket/data/specific",           //lintignore:AWSAT005
+			Expected:    []string{"arn:aws:s3:::bucket/data/specific"}, //lintignore:AWSAT005
+		},
+		{
+			// Covered by the registration, but never granted itself.
+			Name:        "ungranted prefix",
+			ResourceArn: "arn:aws:s3:::bucket/data", //lintignore:AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matchResource := &lakeformation.Resource{
+				DataLocation: &lakeformation.DataLocationResource{
+					ResourceArn: aws.String(testCase.ResourceArn),
+				},
+			}
+
+			collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+				return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+			})
+			collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries()}, true)
+
+			var got []string
+			for _, permission := range collector.matches {
+				got = append(got, aws.StringValue(permission.Resource.DataLocation.ResourceArn))
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected matching locations %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestExpandLakeFormationRegisterDataLocationInput(t *testing.T) {
+	resourceArn := "arn:aws:s3:::example-bucket"         //lintignore:AWSAT003,AWSAT005
+	roleArn := "arn:aws:iam::123456789012:role/register" //lintignore:AWSAT003,AWSAT005
+
+	testCases := []struct {
+		Name     string
+		RoleArn  string
+		Expected *lakeformation.RegisterResourceInput
+	}{
+		{
+			Name: "service-linked role",
+			Expected: &lakeformation.RegisterResourceInput{
+				ResourceArn:          aws.String(resourceArn),
+				UseServiceLinkedRole: aws.Bool(true),
+			},
+		},
+		{
+			Name:    "role",
+			RoleArn: roleArn,
+			Expected: &lakeformation.RegisterResourceInput{
+				ResourceArn: aws.String(resourceArn),
+				RoleArn:     aws.String(roleArn),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := expandLakeFormationRegisterDataLocationInput(resourceArn, testCase.RoleArn)
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPrincipalEquivalent(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Configured string
+		Returned   string
+		Equivalent bool
+	}{
+		{
+			Name:       "account ID",
+			Configured: "111122223333",
+			Returned:   "111122223333",
+			Equivalent: true,
+		},
+		{
+			Name:       "account ID returned as root ARN",
+			Configured: "111122223333",
+			Returned:   "arn:aws:iam::111122223333:root", //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "other account root ARN",
+			Configured: "111122223333",
+			Returned:   "arn:aws:iam::444455556666:root", //lintignore:AWSAT005
+		},
+		{
+			Name:       "account ID and role in account",
+			Configured: "111122223333",
+			Returned:   "arn:aws:iam::111122223333:role/test", //lintignore:AWSAT005
+		},
+		{
+			Name:       "role ARN",
+			Configured: "arn:aws:iam::111122223333:role/test",      //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:role/path/test", //lintignore:AWSAT005
+		},
+		{
+			Name:       "GovCloud role ARN",
+			Configured: "arn:aws-us-gov:iam::111122223333:role/test", //lintignore:AWSAT005
+			Returned:   "arn:aws-us-gov:iam::111122223333:role/test", //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "account ID returned as GovCloud root ARN",
+			Configured: "111122223333",
+			Returned:   "arn:aws-us-gov:iam::111122223333:root", //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "account ID returned as China root ARN",
+			Configured: "111122223333",
+			Returned:   "arn:aws-cn:iam::111122223333:root", //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "external IdP group ARN",
+			Configured: "arn:aws:iam::111122223333:saml-provider/idp1:group/data-scientists", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:saml-provider/idp1:group/data-scientists", //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "external IdP group ARN of other provider",
+			Configured: "arn:aws:iam::111122223333:saml-provider/idp1:group/data-scientists", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:saml-provider/idp2:group/data-scientists", //lintignore:AWSAT005
+		},
+		{
+			Name:       "account ID and external IdP group in account",
+			Configured: "111122223333",
+			Returned:   "arn:aws:iam::111122223333:saml-provider/idp1:group/data-scientists", //lintignore:AWSAT005
+		},
+		{
+			Name:       "role ARN in other partition",
+			Configured: "arn:aws-us-gov:iam::111122223333:role/test", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:role/test",        //lintignore:AWSAT005
+		},
+		{
+			Name:       "assumed role session returned without session name",
+			Configured: "arn:aws:sts::111122223333:assumed-role/test/session", //lintignore:AWSAT005
+			Returned:   "arn:aws:sts::111122223333:assumed-role/test",         //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "assumed role session returned as role ARN",
+			Configured: "arn:aws:sts::111122223333:assumed-role/test/session", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:role/test",                 //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "assumed role session returned as role ARN with path",
+			Configured: "arn:aws:sts::111122223333:assumed-role/test/session", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:role/path/test",            //lintignore:AWSAT005
+			Equivalent: true,
+		},
+		{
+			Name:       "assumed role session of other role",
+			Configured: "arn:aws:sts::111122223333:assumed-role/test/session", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::111122223333:role/other-test",           //lintignore:AWSAT005
+		},
+		{
+			Name:       "assumed role session of role in other account",
+			Configured: "arn:aws:sts::111122223333:assumed-role/test/session", //lintignore:AWSAT005
+			Returned:   "arn:aws:iam::444455556666:role/test",                 //lintignore:AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if _, errs := validatePrincipal(testCase.Configured, "principal"); len(errs) > 0 {
+				t.Fatalf("expected configured principal to be valid: %v", errs)
+			}
+
+			if got := lakeFormationPrincipalEquivalent(testCase.Configured, testCase.Returned); got != testCase.Equivalent {
+				t.Errorf("expected %t, got %t", testCase.Equivalent, got)
+			}
+		})
+	}
+}
+
+func TestFlattenLakeFormationPermissionsPrincipal(t *testing.T) {
+	testCases := []struct {
+		Name                       string
+		Configured                 string
+		Returned                   *lakeformation.DataLakePrincipal
+		ExpectedPrincipal          string
+		ExpectedEffectivePrincipal string
+	}{
+		{
+			Name:                       "same principal",
+			Configured:                 "arn:aws:iam::111122223333:role/test",                                                                            //lintignore:AWSAT005
+			Returned:                   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::111122223333:role/test")}, //lintignore:AWSAT005
+			ExpectedPrincipal:          "arn:aws:iam::111122223333:role/test",                                                                            //lintignore:AWSAT005
+			ExpectedEffectivePrincipal: "arn:aws:iam::111122223333:role/test",                                                                            //lintignore:AWSAT005
+		},
+		{
+			Name:                       "account ID canonicalized to root ARN",
+			Configured:                 "111122223333",
+			Returned:                   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::111122223333:root")}, //lintignore:AWSAT005
+			ExpectedPrincipal:          "111122223333",
+			ExpectedEffectivePrincipal: "arn:aws:iam::111122223333:root", //lintignore:AWSAT005
+		},
+		{
+			Name:                       "different principal",
+			Configured:                 "arn:aws:iam::111122223333:role/test",                                                                                 //lintignore:AWSAT005
+			Returned:                   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:iam::111122223333:role/path/test")}, //lintignore:AWSAT005
+			ExpectedPrincipal:          "arn:aws:iam::111122223333:role/path/test",                                                                            //lintignore:AWSAT005
+			ExpectedEffectivePrincipal: "arn:aws:iam::111122223333:role/path/test",                                                                            //lintignore:AWSAT005
+		},
+		{
+			Name:                       "assumed role session name stripped",
+			Configured:                 "arn:aws:sts::111122223333:assumed-role/test/session",                                                                    //lintignore:AWSAT005
+			Returned:                   &lakeformation.DataLakePrincipal{DataLakePrincipalIdentifier: aws.String("arn:aws:sts::111122223333:assumed-role/test")}, //lintignore:AWSAT005
+			ExpectedPrincipal:          "arn:aws:sts::111122223333:assumed-role/test/session",                                                                    //lintignore:AWSAT005
+			ExpectedEffectivePrincipal: "arn:aws:sts::111122223333:assumed-role/test",                                                                            //lintignore:AWSAT005
+		},
+		{
+			Name:                       "no principal returned",
+			Configured:                 "111122223333",
+			ExpectedPrincipal:          "111122223333",
+			ExpectedEffectivePrincipal: "111122223333",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			principal, effectivePrincipal := flattenLakeFormationPermissionsPrincipal(testCase.Configured, testCase.Returned)
+
+			if principal != testCase.ExpectedPrincipal {
+				t.Errorf("expected principal %q, got %q", testCase.ExpectedPrincipal, principal)
+			}
+
+			if effectivePrincipal != testCase.ExpectedEffectivePrincipal {
+				t.Errorf("expected effective principal %q, got %q", testCase.ExpectedEffectivePrincipal, effectivePrincipal)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsPartitions(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Partition string
+	}{
+		{
+			Name:      "commercial",
+			Partition: "aws",
+		},
+		{
+			Name:      "GovCloud",
+			Partition: "aws-us-gov",
+		},
+		{
+			Name:      "China",
+			Partition: "aws-cn",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			principal := fmt.Sprintf("arn:%s:iam::111122223333:role/test", testCase.Partition)
+			bucketArn := fmt.Sprintf("arn:%s:s3:::example-bucket", testCase.Partition)
+
+			if _, errs := validatePrincipal(principal, "principal"); len(errs) > 0 {
+				t.Errorf("expected principal %s to be valid: %v", principal, errs)
+			}
+
+			if _, errs := validateArn(bucketArn, "arn"); len(errs) > 0 {
+				t.Errorf("expected data location %s to be valid: %v", bucketArn, errs)
+			}
+
+			dataLocation := map[string]interface{}{
+				"arn":        bucketArn,
+				"catalog_id": "111122223333",
+			}
+
+			if got := flattenLakeFormationDataLocationResource(expandLakeFormationDataLocationResource(dataLocation)); !reflect.DeepEqual(got, dataLocation) {
+				t.Errorf("expected data location %v, got %v", dataLocation, got)
+			}
+
+			apiObject := &lakeformation.Resource{DataLocation: expandLakeFormationDataLocationResource(dataLocation)}
+
+			if expected, got := principal+",DATA_LOCATION,111122223333,"+bucketArn, tflakeformation.PermissionsCreateID(principal, "123456789012", apiObject); got != expected {
+				t.Errorf("expected ID %q, got %q", expected, got)
+			}
+
+			if !lakeFormationPrincipalEquivalent("111122223333", fmt.Sprintf("arn:%s:iam::111122223333:root", testCase.Partition)) {
+				t.Errorf("expected account ID to match the %s root ARN", testCase.Partition)
+			}
+		})
+	}
+}
+
+func TestLakeFormationMissingColumns(t *testing.T) {
+	// "email" was renamed to "email_address" after the grant was made.
+	table := &glue.TableData{
+		Name: aws.String("table"),
+		PartitionKeys: []*glue.Column{
+			{Name: aws.String("dt")},
+		},
+		StorageDescriptor: &glue.StorageDescriptor{
+			Columns: []*glue.Column{
+				{Name: aws.String("id")},
+				{Name: aws.String("email_address")},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name        string
+		ColumnNames []string
+		Expected    []string
+	}{
+		{
+			Name:        "all columns exist",
+			ColumnNames: []string{"id", "dt"},
+		},
+		{
+			Name:        "renamed column",
+			ColumnNames: []string{"id", "email"},
+			Expected:    []string{"email"},
+		},
+		{
+			Name:        "case insensitive",
+			ColumnNames: []string{"ID", "Email_Address"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := lakeFormationMissingColumns(aws.StringSlice(testCase.ColumnNames), table)
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationTableIsView(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Table    *glue.TableData
+		Expected bool
+	}{
+		{
+			Name: "missing",
+		},
+		{
+			Name: "table",
+			Table: &glue.TableData{
+				Name:      aws.String("events"),
+				TableType: aws.String("EXTERNAL_TABLE"),
+			},
+		},
+		{
+			Name: "converted to view",
+			Table: &glue.TableData{
+				Name:             aws.String("events"),
+				TableType:        aws.String(glueTableTypeVirtualView),
+				ViewOriginalText: aws.String("SELECT * FROM events_v2"),
+			},
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := lakeFormationTableIsView(testCase.Table); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationRetry_pollInterval(t *testing.T) {
+	pollInterval := 250 * time.Millisecond
+
+	var calls []time.Time
+	err := lakeFormationRetry(context.Background(), 1*time.Minute, pollInterval, func() *resource.RetryError {
+		calls = append(calls, time.Now())
+
+		if len(calls) < 3 {
+			return resource.RetryableError(fmt.Errorf("not yet"))
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+
+	for i := 1; i < len(calls); i++ {
+		if elapsed := calls[i].Sub(calls[i-1]); elapsed < pollInterval {
+			t.Errorf("call %d: expected at least %s since the previous call, got %s", i, pollInterval, elapsed)
+		}
+	}
+
+	err = lakeFormationRetry(context.Background(), 1*time.Minute, pollInterval, func() *resource.RetryError {
+		return resource.NonRetryableError(fmt.Errorf("failed"))
+	})
+
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("expected non-retryable error to be returned, got %v", err)
+	}
+}
+
+func TestLakeFormationRetry_contextDeadline(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		PollInterval time.Duration
+	}{
+		{
+			Name: "default backoff",
+		},
+		{
+			Name:         "poll interval",
+			PollInterval: 50 * time.Millisecond,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// The deadline set from a configured timeout bounds the retries, e.g. of concurrent modifications.
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := lakeFormationRetry(ctx, 1*time.Minute, testCase.PollInterval, func() *resource.RetryError {
+				return resource.RetryableError(awserr.New(lakeformation.ErrCodeConcurrentModificationException, "Concurrent modification", nil))
+			})
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if elapsed := time.Since(start); elapsed > 30*time.Second {
+				t.Errorf("expected the retries to stop at the context deadline, took %s", elapsed)
+			}
+		})
+	}
+}
+
+type testLakeFormationPermissionsHook struct {
+	events []lakeFormationPermissionsEvent
+}
+
+func (h *testLakeFormationPermissionsHook) PermissionsChanged(_ context.Context, event lakeFormationPermissionsEvent) {
+	h.events = append(h.events, event)
+}
+
+func TestLakeFormationPermissionsNotify(t *testing.T) {
+	defaultHook := lakeFormationPermissionsEvents
+	defer func() {
+		lakeFormationPermissionsEvents = defaultHook
+	}()
+
+	if _, ok := defaultHook.(lakeFormationPermissionsNoopHook); !ok {
+		t.Errorf("expected the no-op hook by default, got %T", defaultHook)
+	}
+
+	hook := &testLakeFormationPermissionsHook{}
+	lakeFormationPermissionsEvents = hook
+
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	apiObject := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+
+	lakeFormationPermissionsNotify(context.Background(), lakeFormationPermissionsGrantedEvent(&lakeformation.GrantPermissionsInput{
+		CatalogId:                  aws.String("123456789012"),
+		Permissions:                aws.StringSlice([]string{lakeformation.PermissionSelect, lakeformation.PermissionAlter}),
+		PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Principal:                  principal,
+		Resource:                   apiObject,
+	}))
+	lakeFormationPermissionsNotify(context.Background(), lakeFormationPermissionsRevokedEvent(&lakeformation.RevokePermissionsInput{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Principal:   principal,
+		Resource:    apiObject,
+	}))
+
+	expected := []lakeFormationPermissionsEvent{
+		{
+			Action:                     lakeFormationPermissionsEventGrant,
+			CatalogId:                  "123456789012",
+			Principal:                  "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT003,AWSAT005
+			Resource:                   apiObject,
+			Permissions:                []string{lakeformation.PermissionAlter, lakeformation.PermissionSelect},
+			PermissionsWithGrantOption: []string{lakeformation.PermissionSelect},
+		},
+		{
+			Action:                     lakeFormationPermissionsEventRevoke,
+			Principal:                  "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT003,AWSAT005
+			Resource:                   apiObject,
+			Permissions:                []string{lakeformation.PermissionAlter},
+			PermissionsWithGrantOption: []string{},
+		},
+	}
+
+	if !reflect.DeepEqual(hook.events, expected) {
+		t.Errorf("expected events %v, got %v", expected, hook.events)
+	}
+
+	lakeFormationPermissionsEvents = nil
+	lakeFormationPermissionsNotify(context.Background(), lakeFormationPermissionsRevokedEvent(&lakeformation.RevokePermissionsInput{Principal: principal}))
+}
+
+func TestExpandLakeFormationPermissionsFailureDiagnostics(t *testing.T) {
+	principal := "arn:aws:iam::123456789012:role/test" //lintignore:AWSAT003,AWSAT005
+	apiObject := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	err := fmt.Errorf("error creating Lake Formation Permissions: %w", awserr.NewRequestFailure(awserr.New(lakeformation.ErrCodeInvalidInputException, "Invalid principal", nil), 400, "6a0bd3c5-d1a8-4b9b-9b1e-7d3e2f6c0a11"))
+
+	diags := expandLakeFormationPermissionsFailureDiagnostics(err, principal, apiObject, false)
+
+	if len(diags) != 1 || diags[0].Severity != diag.Error || diags[0].Detail != "" {
+		t.Fatalf("expected a single error diagnostic without detail, got %v", diags)
+	}
+
+	diags = expandLakeFormationPermissionsFailureDiagnostics(err, principal, apiObject, true)
+
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected a single error diagnostic, got %v", diags)
+	}
+
+	if diags[0].Summary != err.Error() {
+		t.Errorf("expected summary %q, got %q", err.Error(), diags[0].Summary)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(diags[0].Detail), &got); err != nil {
+		t.Fatalf("expected JSON detail, got %q: %s", diags[0].Detail, err)
+	}
+
+	expected := map[string]interface{}{
+		"principal":     principal,
+		"resource_type": lakeformation.DataLakeResourceTypeTable,
+		"identifier":    "db.tbl",
+		"error_code":    lakeformation.ErrCodeInvalidInputException,
+		"request_id":    "6a0bd3c5-d1a8-4b9b-9b1e-7d3e2f6c0a11",
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected detail %v, got %v", expected, got)
+	}
+}
+
+func TestLakeFormationRetryLimit(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		MaxRetries    int
+		FailedCalls   int
+		ExpectedCalls int
+		ExpectedError bool
+	}{
+		{
+			Name:          "capped",
+			MaxRetries:    2,
+			FailedCalls:   5,
+			ExpectedCalls: 3,
+			ExpectedError: true,
+		},
+		{
+			Name:          "success within cap",
+			MaxRetries:    2,
+			FailedCalls:   2,
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "unlimited",
+			FailedCalls:   5,
+			ExpectedCalls: 6,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var calls int
+			err := lakeFormationRetry(context.Background(), 1*time.Minute, 10*time.Millisecond, lakeFormationRetryLimit(testCase.MaxRetries, func() *resource.RetryError {
+				calls++
+
+				if calls <= testCase.FailedCalls {
+					return resource.RetryableError(awserr.New(lakeformation.ErrCodeConcurrentModificationException, "Concurrent modification", nil))
+				}
+				return nil
+			}))
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("expected %d calls, got %d", testCase.ExpectedCalls, calls)
+			}
+
+			if !testCase.ExpectedError {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if isResourceTimeoutError(err) {
+				t.Errorf("expected the attempt cap rather than the timeout to end the retries, got %s", err)
+			}
+
+			if !tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeConcurrentModificationException) {
+				t.Errorf("expected the last error to be wrapped, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLakeFormationRetryErrors(t *testing.T) {
+	retryErrors := &lakeFormationRetryErrors{}
+
+	attempts := []error{
+		awserr.New(lakeFormationErrCodeThrottlingException, "Rate exceeded", nil),
+		awserr.New(lakeformation.ErrCodeConcurrentModificationException, "Concurrent modification", nil),
+		awserr.New(lakeFormationErrCodeThrottlingException, "Rate exceeded", nil),
+	}
+
+	var calls int
+	err := lakeFormationRetry(context.Background(), 1*time.Minute, 10*time.Millisecond, retryErrors.wrap(func() *resource.RetryError {
+		calls++
+
+		if calls <= len(attempts) {
+			return resource.RetryableError(attempts[calls-1])
+		}
+		return resource.NonRetryableError(fmt.Errorf("error creating Lake Formation Permissions: %w", awserr.New(lakeformation.ErrCodeInvalidInputException, "Invalid principal", nil)))
+	}))
+
+	err = retryErrors.annotate(err)
+
+	if !tfawserr.ErrCodeEquals(err, lakeformation.ErrCodeInvalidInputException) {
+		t.Fatalf("expected the last error to be wrapped, got %v", err)
+	}
+
+	expected := "error creating Lake Formation Permissions: InvalidInputException: Invalid principal (errors across retries: ThrottlingException: Rate exceeded; ConcurrentModificationException: Concurrent modification; InvalidInputException: Invalid principal)"
+
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	single := &lakeFormationRetryErrors{}
+	failed := awserr.New(lakeFormationErrCodeThrottlingException, "Rate exceeded", nil)
+	single.record(failed)
+
+	if got := single.annotate(failed); got != failed {
+		t.Errorf("expected a single distinct error to be returned unchanged, got %v", got)
+	}
+
+	if got := single.annotate(nil); got != nil {
+		t.Errorf("expected no error, got %v", got)
+	}
+}
+
+func TestLakeFormationPermissionsChangeSummary(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Old      []interface{}
+		New      []interface{}
+		Expected string
+	}{
+		{
+			Name:     "unchanged",
+			Old:      []interface{}{lakeformation.PermissionSelect},
+			New:      []interface{}{lakeformation.PermissionSelect},
+			Expected: "unchanged",
+		},
+		{
+			Name:     "grants and revokes",
+			Old:      []interface{}{lakeformation.PermissionSelect, lakeformation.PermissionDrop, lakeformation.PermissionAlter},
+			New:      []interface{}{lakeformation.PermissionSelect, lakeformation.PermissionInsert, lakeformation.PermissionDelete},
+			Expected: "grant [DELETE, INSERT], revoke [ALTER, DROP]",
+		},
+		{
+			Name:     "grant option removed",
+			Old:      []interface{}{lakeformation.PermissionSelect},
+			New:      []interface{}{},
+			Expected: "grant [], revoke [SELECT]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := lakeFormationPermissionsChangeSummary(schema.NewSet(schema.HashString, testCase.Old), schema.NewSet(schema.HashString, testCase.New))
+
+			if got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsCatalogConflict(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		CatalogResource bool
+		CatalogId       string
+		BlockCatalogIds map[string]string
+		ExpectError     bool
+	}{
+		{
+			Name:            "catalog resource only",
+			CatalogResource: true,
+		},
+		{
+			Name:            "catalog resource with catalog ID",
+			CatalogResource: true,
+			CatalogId:       "123456789012",
+		},
+		{
+			Name:            "block without catalog resource",
+			BlockCatalogIds: map[string]string{"database": "111122223333"},
+		},
+		{
+			Name:            "catalog resource with matching block catalog ID",
+			CatalogResource: true,
+			CatalogId:       "123456789012",
+			BlockCatalogIds: map[string]string{"database": "123456789012"},
+		},
+		{
+			Name:            "catalog resource with contradictory block catalog ID",
+			CatalogResource: true,
+			CatalogId:       "123456789012",
+			BlockCatalogIds: map[string]string{"database": "111122223333"},
+			ExpectError:     true,
+		},
+		{
+			Name:            "catalog resource with only block catalog ID",
+			CatalogResource: true,
+			BlockCatalogIds: map[string]string{"table": "111122223333"},
+			ExpectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := lakeFormationPermissionsCatalogConflict(testCase.CatalogResource, testCase.CatalogId, testCase.BlockCatalogIds)
+
+			if testCase.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLakeFormationValidateColumnCount(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Count       int
+		ExpectError bool
+	}{
+		{
+			Name: "none",
+		},
+		{
+			Name:  "at limit",
+			Count: lakeFormationMaxColumnsPerGrant,
+		},
+		{
+			Name:        "above limit",
+			Count:       lakeFormationMaxColumnsPerGrant + 1,
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := lakeFormationValidateColumnCount("table_with_columns.0.column_names", testCase.Count)
+
+			if testCase.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLakeFormationTableResourceSpecialCharacters(t *testing.T) {
+	names := []string{
+		"events.v2",
+		"raw/events",
+		"événements_日本",
+		"name with spaces",
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			table := map[string]interface{}{
+				"catalog_id":    "123456789012",
+				"database_name": name,
+				"name":          name,
+				"wildcard":      false,
+			}
+
+			if got := flattenLakeFormationTableResource(expandLakeFormationTableResource(table)); got["database_name"] != name || got["name"] != name {
+				t.Errorf("table names did not round-trip: %v", got)
+			}
+
+			tableWithColumns := map[string]interface{}{
+				"catalog_id":            "123456789012",
+				"column_names":          []interface{}{name, "plain"},
+				"database_name":         name,
+				"excluded_column_names": []interface{}{},
+				"name":                  name,
+			}
+
+			got := flattenLakeFormationTableWithColumnsResource(expandLakeFormationTableWithColumnsResource(tableWithColumns))
+
+			if got["database_name"] != name || got["name"] != name {
+				t.Errorf("table with columns names did not round-trip: %v", got)
+			}
+
+			if columns := got["column_names"].([]interface{}); len(columns) != 2 || columns[0] != name {
+				t.Errorf("column names did not round-trip: %v", columns)
+			}
+
+			input := &lakeformation.GrantPermissionsInput{
+				Resource: &lakeformation.Resource{
+					Table: expandLakeFormationTableResource(table),
+				},
+			}
+
+			if !strings.Contains(input.String(), name) {
+				t.Errorf("ID source %q does not contain name %q", input.String(), name)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsFederatedQualifiedNames(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		DatabaseName string
+		TableName    string
+	}{
+		{
+			Name:         "connection prefix",
+			DatabaseName: "mysql_connection/sales",
+			TableName:    "mysql_connection/sales/orders",
+		},
+		{
+			Name:         "dotted",
+			DatabaseName: "federated.sales",
+			TableName:    "public.orders",
+		},
+		{
+			Name:         "colon",
+			DatabaseName: "redshift:dev",
+			TableName:    "dev:public.orders",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			database := map[string]interface{}{
+				"catalog_id": "123456789012",
+				"name":       testCase.DatabaseName,
+			}
+
+			if got := flattenLakeFormationDatabaseResource(expandLakeFormationDatabaseResource(database)); !reflect.DeepEqual(got, database) {
+				t.Errorf("expected database %v, got %v", database, got)
+			}
+
+			table := map[string]interface{}{
+				"catalog_id":    "123456789012",
+				"database_name": testCase.DatabaseName,
+				"name":          testCase.TableName,
+			}
+
+			if got := flattenLakeFormationTableResource(expandLakeFormationTableResource(table)); !reflect.DeepEqual(got, table) {
+				t.Errorf("expected table %v, got %v", table, got)
+			}
+
+			tableWithColumns := map[string]interface{}{
+				"catalog_id":    "123456789012",
+				"column_names":  []interface{}{"id"},
+				"database_name": testCase.DatabaseName,
+				"name":          testCase.TableName,
+			}
+
+			if got := flattenLakeFormationTableWithColumnsResource(expandLakeFormationTableWithColumnsResource(tableWithColumns)); got["database_name"] != testCase.DatabaseName || got["name"] != testCase.TableName {
+				t.Errorf("expected table with columns %v, got %v", tableWithColumns, got)
+			}
+
+			// ListPermissions echoes the qualified names but may omit the catalog ID.
+			in := lakeformation.Resource{Table: expandLakeFormationTableResource(table)}
+			out := lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String(testCase.DatabaseName),
+					Name:         aws.String(testCase.TableName),
+				},
+			}
+
+			if !tflakeformation.ResourceEqual(in, tflakeformation.ResourceWithEffectiveCatalogID(out, "123456789012")) {
+				t.Errorf("expected qualified table %v to match %v", in, out)
+			}
+
+			if tflakeformation.TableResourceIsWildcard(in.Table) {
+				t.Errorf("expected qualified table %s not to be treated as a wildcard", testCase.TableName)
+			}
+
+			if expected, got := testCase.DatabaseName+"."+testCase.TableName, lakeFormationResourceIdentifier(&in); got != expected {
+				t.Errorf("expected identifier %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationTableWithColumnsResourceForConfig(t *testing.T) {
+	both := func() *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:   aws.String("123456789012"),
+					ColumnNames: aws.StringSlice([]string{"event", "timestamp"}),
+					ColumnWildcard: &lakeformation.ColumnWildcard{
+						ExcludedColumnNames: aws.StringSlice([]string{"value"}),
+					},
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Config   map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name: "column names",
+			Config: map[string]interface{}{
+				"catalog_id":            "123456789012",
+				"column_names":          []interface{}{"event", "timestamp"},
+				"database_name":         "db",
+				"excluded_column_names": []interface{}{},
+				"name":                  "tbl",
+			},
+			Expected: map[string]interface{}{
+				"catalog_id":    "123456789012",
+				"column_names":  []interface{}{"event", "timestamp"},
+				"database_name": "db",
+				"name":          "tbl",
+				"wildcard":      false,
+			},
+		},
+		{
+			Name: "excluded column names",
+			Config: map[string]interface{}{
+				"catalog_id":            "123456789012",
+				"column_names":          []interface{}{},
+				"database_name":         "db",
+				"excluded_column_names": []interface{}{"value"},
+				"name":                  "tbl",
+			},
+			Expected: map[string]interface{}{
+				"catalog_id":            "123456789012",
+				"column_names":          []interface{}{},
+				"database_name":         "db",
+				"excluded_column_names": []interface{}{"value"},
+				"name":                  "tbl",
+				"wildcard":              false,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matchResource := &lakeformation.Resource{
+				TableWithColumns: expandLakeFormationTableWithColumnsResource(testCase.Config),
+			}
+			permission := both()
+
+			if !tflakeformation.ResourceEqual(*matchResource, *permission.Resource) {
+				t.Errorf("expected %v to match %v", permission.Resource, matchResource)
+			}
+
+			apiObject := resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+
+			if apiObject == nil {
+				t.Fatalf("expected %v to match %v", permission.Resource, matchResource)
+			}
+
+			if got := flattenLakeFormationTableWithColumnsResource(apiObject.TableWithColumns); !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+
+	// Without a configuration, such as on import, the explicit column names are kept.
+	expected := map[string]interface{}{
+		"catalog_id":    "123456789012",
+		"column_names":  []interface{}{"event", "timestamp"},
+		"database_name": "db",
+		"name":          "tbl",
+		"wildcard":      false,
+	}
+
+	if got := flattenLakeFormationTableWithColumnsResource(both().Resource.TableWithColumns); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestLakeFormationTableWithColumnsResourceEmptyExclusionsWildcard(t *testing.T) {
+	in := expandLakeFormationTableWithColumnsResource(map[string]interface{}{
+		"catalog_id":            "123456789012",
+		"column_names":          []interface{}{},
+		"database_name":         "db",
+		"excluded_column_names": []interface{}{},
+		"name":                  "tbl",
+		"wildcard":              true,
+	})
+
+	if in.ColumnWildcard == nil || len(in.ColumnWildcard.ExcludedColumnNames) != 0 || len(in.ColumnNames) != 0 {
+		t.Fatalf("expected a column wildcard without excluded columns, got %v", in)
+	}
+
+	table := func(columnNames []string, columnWildcard *lakeformation.ColumnWildcard) *lakeformation.TableWithColumnsResource {
+		return &lakeformation.TableWithColumnsResource{
+			CatalogId:      aws.String("123456789012"),
+			ColumnNames:    aws.StringSlice(columnNames),
+			ColumnWildcard: columnWildcard,
+			DatabaseName:   aws.String("db"),
+			Name:           aws.String("tbl"),
+		}
+	}
+
+	testCases := []struct {
+		Name             string
+		Out              *lakeformation.TableWithColumnsResource
+		ExpectedMatch    bool
+		ExpectedWildcard bool
+	}{
+		{
+			Name:             "wildcard without excluded columns",
+			Out:              table(nil, &lakeformation.ColumnWildcard{}),
+			ExpectedMatch:    true,
+			ExpectedWildcard: true,
+		},
+		{
+			Name:             "wildcard with empty excluded columns",
+			Out:              table(nil, &lakeformation.ColumnWildcard{ExcludedColumnNames: []*string{}}),
+			ExpectedMatch:    true,
+			ExpectedWildcard: true,
+		},
+		{
+			Name: "wildcard with excluded columns",
+			Out:  table(nil, &lakeformation.ColumnWildcard{ExcludedColumnNames: aws.StringSlice([]string{"value"})}),
+		},
+		{
+			Name: "no wildcard",
+			Out:  table([]string{"event"}, nil),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := tflakeformation.ResourceEqual(lakeformation.Resource{TableWithColumns: in}, lakeformation.Resource{TableWithColumns: testCase.Out}); got != testCase.ExpectedMatch {
+				t.Errorf("expected match %t, got %t", testCase.ExpectedMatch, got)
+			}
+
+			tfMap := flattenLakeFormationTableWithColumnsResource(testCase.Out)
+
+			if got := tfMap["wildcard"]; got != testCase.ExpectedWildcard {
+				t.Errorf("expected wildcard %t, got %v", testCase.ExpectedWildcard, got)
+			}
+
+			// Only a column wildcard reports excluded columns, even when there are none.
+			if _, got := tfMap["excluded_column_names"]; got != (testCase.Out.ColumnWildcard != nil) {
+				t.Errorf("expected excluded_column_names set %t, got %v", testCase.Out.ColumnWildcard != nil, tfMap)
+			}
+		})
+	}
+
+	_, _, _, apiObjects, err := tflakeformation.PermissionsParseID("arn:aws:iam::123456789012:role/test,TABLE_WITH_COLUMNS,,db,tbl,*") //lintignore:AWSAT003,AWSAT005
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if apiObject := apiObjects[0]; apiObject.TableWithColumns == nil {
+		t.Errorf("expected an imported table with columns, got %v", apiObject)
+	} else if v := apiObject.TableWithColumns; v == nil || v.ColumnWildcard == nil || len(v.ColumnWildcard.ExcludedColumnNames) != 0 || len(v.ColumnNames) != 0 {
+		t.Errorf("expected an imported column wildcard without excluded columns, got %v", apiObject)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_skipSelectCompanion(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	selectPermissionsResource := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			ColumnWildcard: &lakeformation.ColumnWildcard{},
+			DatabaseName:   aws.String("db"),
+			Name:           aws.String("tbl"),
+		},
+	}
+	companion := func() *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("tbl"),
+				},
+			},
+		}
+	}
+	table := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("tbl"),
+			},
+		},
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, selectPermissionsResource, "123456789012", companion()) == nil {
+		t.Error("expected the SELECT companion to match by default")
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", companion()) != nil {
+		t.Error("expected the SELECT companion not to match when companion matching is skipped")
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", table) == nil {
+		t.Error("expected the table entry to match when companion matching is skipped")
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", nil) != nil {
+		t.Error("expected a nil entry not to match")
+	}
+}
+
+func TestLakeFormationCollapseBroadPermissions(t *testing.T) {
+	// ALL is the broadest permission currently available and stands in for any admin-equivalent permission.
+	testCases := []struct {
+		Name        string
+		Permissions []string
+		Configured  []interface{}
+		Expected    []string
+	}{
+		{
+			Name:        "no broad permission",
+			Permissions: []string{lakeformation.PermissionAlter, lakeformation.PermissionSelect},
+			Configured:  []interface{}{lakeformation.PermissionAlter, lakeformation.PermissionSelect},
+			Expected:    []string{lakeformation.PermissionAlter, lakeformation.PermissionSelect},
+		},
+		{
+			Name:        "broad permission only",
+			Permissions: []string{lakeformation.PermissionAll},
+			Configured:  []interface{}{lakeformation.PermissionAll},
+			Expected:    []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:        "broad permission with implied permission",
+			Permissions: []string{lakeformation.PermissionAll, lakeformation.PermissionSelect},
+			Configured:  []interface{}{lakeformation.PermissionAll},
+			Expected:    []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:        "broad permission with configured permission",
+			Permissions: []string{lakeformation.PermissionAll, lakeformation.PermissionSelect},
+			Configured:  []interface{}{lakeformation.PermissionAll, lakeformation.PermissionSelect},
+			Expected:    []string{lakeformation.PermissionAll, lakeformation.PermissionSelect},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := lakeFormationCollapseBroadPermissions(testCase.Permissions, schema.NewSet(schema.HashString, testCase.Configured))
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsCollector(t *testing.T) {
+	const pages = 1500
+	const entriesPerPage = 100
+
+	matchResource := &lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			CatalogId: aws.String("123456789012"),
+			Name:      aws.String("match"),
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+
+	var calls int
+	for i := 0; i < pages; i++ {
+		page := &lakeformation.ListPermissionsOutput{}
+
+		for j := 0; j < entriesPerPage; j++ {
+			name := fmt.Sprintf("db_%d_%d", i, j)
+			if i == 700 && j == 42 {
+				name = "match"
+			}
+
+			page.PrincipalResourcePermissions = append(page.PrincipalResourcePermissions, &lakeformation.PrincipalResourcePermissions{
+				Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+				Resource: &lakeformation.Resource{
+					Database: &lakeformation.DatabaseResource{
+						Name: aws.String(name),
+					},
+				},
+			})
+		}
+
+		calls++
+		if !collector.page(page, i == pages-1) {
+			break
+		}
+	}
+
+	if calls != pages {
+		t.Errorf("expected %d pages to be read, got %d", pages, calls)
+	}
+
+	if len(collector.matches) != 1 || cap(collector.matches) > 1 {
+		t.Fatalf("expected only the single match to be kept, got %d entries (capacity %d)", len(collector.matches), cap(collector.matches))
+	}
+
+	if got := aws.StringValue(collector.matches[0].Resource.Database.Name); got != "match" {
+		t.Errorf("expected matching database, got %s", got)
+	}
+
+	if !collector.page(nil, false) {
+		t.Error("expected an empty page not to stop pagination")
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_crossRegionShare(t *testing.T) {
+	// The owner catalog is in another account and the resource share in another region. Catalog IDs are account
+	// IDs, so nothing about the grant depends on the region it was shared from.
+	ownerCatalogId := "111122223333"
+
+	database := map[string]interface{}{
+		"catalog_id": ownerCatalogId,
+		"name":       "shared_db",
+	}
+
+	if got := flattenLakeFormationDatabaseResource(expandLakeFormationDatabaseResource(database)); !reflect.DeepEqual(got, database) {
+		t.Errorf("expected database %v, got %v", database, got)
+	}
+
+	matchResource := &lakeformation.Resource{Database: expandLakeFormationDatabaseResource(database)}
+	permission := &lakeformation.PrincipalResourcePermissions{
+		AdditionalDetails: &lakeformation.DetailsMap{
+			ResourceShare: aws.StringSlice([]string{"arn:aws:ram:eu-west-1:111122223333:resource-share/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111"}), //lintignore:AWSAT003,AWSAT005
+		},
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+		Resource: &lakeformation.Resource{
+			Database: &lakeformation.DatabaseResource{
+				CatalogId: aws.String(ownerCatalogId),
+				Name:      aws.String("shared_db"),
+			},
+		},
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission) == nil {
+		t.Errorf("expected cross-region shared grant %v to match %v", permission.Resource, matchResource)
+	}
+
+	if expected, got := "arn:aws:iam::123456789012:role/test,DATABASE,"+ownerCatalogId+",shared_db", tflakeformation.PermissionsCreateID("arn:aws:iam::123456789012:role/test", "123456789012", matchResource); got != expected { //lintignore:AWSAT003,AWSAT005
+		t.Errorf("expected ID %q, got %q", expected, got)
+	}
+
+	dataLocation := map[string]interface{}{
+		"arn":        "arn:aws:s3:::shared-bucket-eu-west-1/prefix", //lintignore:AWSAT003,AWSAT005
+		"catalog_id": ownerCatalogId,
+	}
+
+	if got := flattenLakeFormationDataLocationResource(expandLakeFormationDataLocationResource(dataLocation)); !reflect.DeepEqual(got, dataLocation) {
+		t.Errorf("expected data location %v, got %v", dataLocation, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_identicalGrantsInTwoCatalogs(t *testing.T) {
+	callerCatalogId := "123456789012"
+	otherCatalogId := "111122223333"
+
+	table := func(catalogId string, permission string) *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{permission}),
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+			},
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(catalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name                string
+		CatalogId           *string
+		ExpectedCatalogId   string
+		ExpectedPermissions []string
+	}{
+		{
+			Name:                "catalog ID omitted",
+			ExpectedCatalogId:   callerCatalogId,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter},
+		},
+		{
+			Name:                "caller catalog ID",
+			CatalogId:           aws.String(callerCatalogId),
+			ExpectedCatalogId:   callerCatalogId,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter},
+		},
+		{
+			Name:                "other catalog ID",
+			CatalogId:           aws.String(otherCatalogId),
+			ExpectedCatalogId:   otherCatalogId,
+			ExpectedPermissions: []string{lakeformation.PermissionDrop},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matchResource := &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    testCase.CatalogId,
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			}
+			// The grant in the other catalog is listed first so that it would be adopted by a configuration
+			// without a catalog ID if catalog IDs were copied from the entries.
+			entries := []*lakeformation.PrincipalResourcePermissions{
+				table(otherCatalogId, lakeformation.PermissionDrop),
+				table(callerCatalogId, lakeformation.PermissionAlter),
+			}
+
+			collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+				return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, callerCatalogId, permission)
+			})
+			collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+			if len(collector.matches) != 1 {
+				t.Fatalf("expected a single matching entry, got %d: %v", len(collector.matches), collector.matches)
+			}
+
+			if got := aws.StringValue(collector.matches[0].Resource.Table.CatalogId); got != testCase.ExpectedCatalogId {
+				t.Errorf("expected catalog ID %s, got %s", testCase.ExpectedCatalogId, got)
+			}
+
+			if got := flattenLakeFormationPermissions(collector.matches); !reflect.DeepEqual(got, testCase.ExpectedPermissions) {
+				t.Errorf("expected permissions %v, got %v", testCase.ExpectedPermissions, got)
+			}
+
+			if got := matchResource.Table.CatalogId; !reflect.DeepEqual(got, testCase.CatalogId) {
+				t.Errorf("expected the configured catalog ID to be left as-is, got %v", aws.StringValue(got))
+			}
+		})
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_listedEntryUnchanged(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	// ListPermissions omitted the catalog ID of the grant.
+	entry := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("tbl"),
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: []*lakeformation.PrincipalResourcePermissions{entry}}, true)
+
+	if len(collector.matches) != 1 {
+		t.Fatalf("expected a single matching entry, got %d: %v", len(collector.matches), collector.matches)
+	}
+
+	if got := aws.StringValue(collector.matches[0].Resource.Table.CatalogId); got != "123456789012" {
+		t.Errorf("expected the collected entry to have catalog ID 123456789012, got %s", got)
+	}
+
+	if v := entry.Resource.Table.CatalogId; v != nil {
+		t.Errorf("expected the listed entry not to be modified, got catalog ID %s", aws.StringValue(v))
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_tableWithColumnsCompanion(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			CatalogId:    aws.String("123456789012"),
+			ColumnNames:  aws.StringSlice([]string{"event", "timestamp"}),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+
+	// AWS returns the companion table entry alongside the table with columns entries for the same table.
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:    aws.String("123456789012"),
+					ColumnNames:  aws.StringSlice([]string{"timestamp", "event"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("tbl"),
+				},
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	aggregated := tflakeformation.PermissionsAggregate(collector.matches)
+
+	if len(aggregated) != 1 {
+		t.Fatalf("expected only the table with columns entry to match, got %d: %v", len(aggregated), aggregated)
+	}
+
+	if got, expected := flattenLakeFormationPermissions(aggregated), []string{lakeformation.PermissionSelect}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	tableBlock, tableWithColumnsBlock := flattenLakeFormationPermissionsTableBlocks(DataLakeResourceTypeTableWithColumns, aggregated)
+
+	if tableBlock != nil {
+		t.Errorf("expected no table block, got %v", tableBlock)
+	}
+
+	if len(tableWithColumnsBlock) != 1 {
+		t.Fatalf("expected a table_with_columns block, got %v", tableWithColumnsBlock)
+	}
+
+	if got, expected := tableWithColumnsBlock[0].(map[string]interface{})["column_names"], []interface{}{"timestamp", "event"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected column names %v, got %v", expected, got)
+	}
+}
+
+func TestLakeFormationPermissionsResolveMultipleMatches(t *testing.T) {
+	entry := func(catalogId, permission string) *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{permission}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(catalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		}
+	}
+	companion := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Resource: &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:      aws.String("123456789012"),
+				ColumnWildcard: &lakeformation.ColumnWildcard{},
+				DatabaseName:   aws.String("db"),
+				Name:           aws.String("tbl"),
+			},
+		},
+	}
+
+	single := []*lakeformation.PrincipalResourcePermissions{entry("123456789012", lakeformation.PermissionAlter)}
+	// Overlapping grants on the same table made by different pipelines, along with the SELECT companion.
+	duplicates := []*lakeformation.PrincipalResourcePermissions{
+		entry("123456789012", lakeformation.PermissionDescribe),
+		companion,
+		entry("123456789012", lakeformation.PermissionSelect),
+		entry("123456789012", lakeformation.PermissionDescribe),
+	}
+	multiple := []*lakeformation.PrincipalResourcePermissions{
+		entry("123456789012", lakeformation.PermissionAlter),
+		entry("111122223333", lakeformation.PermissionDelete),
+		entry("123456789012", lakeformation.PermissionDrop),
+		entry("444455556666", lakeformation.PermissionInsert),
+	}
+	two := []*lakeformation.PrincipalResourcePermissions{
+		entry("123456789012", lakeformation.PermissionAlter),
+		entry("111122223333", lakeformation.PermissionDelete),
+	}
+	multipleWithCompanion := []*lakeformation.PrincipalResourcePermissions{
+		entry("123456789012", lakeformation.PermissionAlter),
+		entry("111122223333", lakeformation.PermissionDelete),
+		companion,
+		entry("123456789012", lakeformation.PermissionDrop),
+	}
+
+	testCases := []struct {
+		Name                string
+		Mode                string
+		ApiObjects          []*lakeformation.PrincipalResourcePermissions
+		ExpectedError       bool
+		ExpectedPermissions []string
+	}{
+		{
+			Name:                "error single",
+			Mode:                lakeFormationMultipleMatchesError,
+			ApiObjects:          single,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter},
+		},
+		{
+			Name:          "error multiple",
+			Mode:          lakeFormationMultipleMatchesError,
+			ApiObjects:    multiple,
+			ExpectedError: true,
+		},
+		{
+			Name:                "error duplicates",
+			Mode:                lakeFormationMultipleMatchesError,
+			ApiObjects:          duplicates,
+			ExpectedPermissions: []string{lakeformation.PermissionDescribe, lakeformation.PermissionSelect},
+		},
+		{
+			Name:                "error single with companion",
+			Mode:                lakeFormationMultipleMatchesError,
+			ApiObjects:          []*lakeformation.PrincipalResourcePermissions{entry("123456789012", lakeformation.PermissionSelect), companion},
+			ExpectedPermissions: []string{lakeformation.PermissionSelect},
+		},
+		{
+			Name:          "error two resources",
+			Mode:          lakeFormationMultipleMatchesError,
+			ApiObjects:    two,
+			ExpectedError: true,
+		},
+		{
+			Name:          "error multiple with companion",
+			Mode:          lakeFormationMultipleMatchesError,
+			ApiObjects:    multipleWithCompanion,
+			ExpectedError: true,
+		},
+		{
+			Name:                "warn multiple",
+			Mode:                lakeFormationMultipleMatchesWarn,
+			ApiObjects:          multiple,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter, lakeformation.PermissionDrop},
+		},
+		{
+			Name:                "warn multiple with companion",
+			Mode:                lakeFormationMultipleMatchesWarn,
+			ApiObjects:          multipleWithCompanion,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter, lakeformation.PermissionSelect, lakeformation.PermissionDrop},
+		},
+		{
+			Name:                "aggregate multiple",
+			Mode:                lakeFormationMultipleMatchesAggregate,
+			ApiObjects:          multiple,
+			ExpectedPermissions: []string{lakeformation.PermissionAlter, lakeformation.PermissionDelete, lakeformation.PermissionDrop, lakeformation.PermissionInsert},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := lakeFormationPermissionsResolveMultipleMatches("test", testCase.Mode, testCase.ApiObjects)
+
+			if testCase.ExpectedError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if permissions := flattenLakeFormationPermissions(got); !reflect.DeepEqual(permissions, testCase.ExpectedPermissions) {
+				t.Errorf("expected permissions %v, got %v", testCase.ExpectedPermissions, permissions)
+			}
+		})
+	}
+}
+
+func TestLakeFormationWithExpandedAllPermission(t *testing.T) {
+	tableExpansion := []string{
+		lakeformation.PermissionAlter,
+		lakeformation.PermissionDelete,
+		lakeformation.PermissionDescribe,
+		lakeformation.PermissionDrop,
+		lakeformation.PermissionInsert,
+		lakeformation.PermissionSelect,
+	}
+
+	testCases := []struct {
+		Name                       string
+		ResourceType               string
+		Permissions                []string
+		GrantPermissions           []string
+		ExpectedPermissions        []string
+		ExpectedGrantPermissions   []string
+		ConfiguredPermissions      []interface{}
+		ConfiguredGrantPermissions []interface{}
+	}{
+		{
+			Name:                       "grant options expanded",
+			ResourceType:               lakeformation.DataLakeResourceTypeTable,
+			Permissions:                []string{lakeformation.PermissionAll},
+			GrantPermissions:           tableExpansion,
+			ConfiguredPermissions:      []interface{}{lakeformation.PermissionAll},
+			ConfiguredGrantPermissions: []interface{}{lakeformation.PermissionAll},
+			ExpectedPermissions:        []string{lakeformation.PermissionAll},
+			ExpectedGrantPermissions:   []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:                       "permissions expanded",
+			ResourceType:               lakeformation.DataLakeResourceTypeDatabase,
+			Permissions:                []string{lakeformation.PermissionAlter, lakeformation.PermissionCreateTable, lakeformation.PermissionDescribe, lakeformation.PermissionDrop},
+			GrantPermissions:           []string{lakeformation.PermissionAll},
+			ConfiguredPermissions:      []interface{}{lakeformation.PermissionAll},
+			ConfiguredGrantPermissions: []interface{}{lakeformation.PermissionAll},
+			ExpectedPermissions:        []string{lakeformation.PermissionAll},
+			ExpectedGrantPermissions:   []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:                       "grant options partially granted",
+			ResourceType:               lakeformation.DataLakeResourceTypeTable,
+			Permissions:                []string{lakeformation.PermissionAll},
+			GrantPermissions:           []string{lakeformation.PermissionSelect},
+			ConfiguredPermissions:      []interface{}{lakeformation.PermissionAll},
+			ConfiguredGrantPermissions: []interface{}{lakeformation.PermissionAll},
+			ExpectedPermissions:        []string{lakeformation.PermissionAll},
+			ExpectedGrantPermissions:   []string{lakeformation.PermissionSelect},
+		},
+		{
+			Name:                       "expanded without ALL",
+			ResourceType:               lakeformation.DataLakeResourceTypeTable,
+			Permissions:                tableExpansion,
+			GrantPermissions:           tableExpansion,
+			ConfiguredPermissions:      []interface{}{lakeformation.PermissionAlter, lakeformation.PermissionDelete, lakeformation.PermissionDescribe, lakeformation.PermissionDrop, lakeformation.PermissionInsert, lakeformation.PermissionSelect},
+			ConfiguredGrantPermissions: []interface{}{lakeformation.PermissionAlter, lakeformation.PermissionDelete, lakeformation.PermissionDescribe, lakeformation.PermissionDrop, lakeformation.PermissionInsert, lakeformation.PermissionSelect},
+			ExpectedPermissions:        tableExpansion,
+			ExpectedGrantPermissions:   tableExpansion,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			permissions := tflakeformation.PermissionsWithExpandedAll(testCase.ResourceType, testCase.Permissions, testCase.GrantPermissions)
+			grantPermissions := tflakeformation.PermissionsWithExpandedAll(testCase.ResourceType, testCase.GrantPermissions, permissions)
+
+			permissions = lakeFormationCollapseBroadPermissions(permissions, schema.NewSet(schema.HashString, testCase.ConfiguredPermissions))
+			grantPermissions = lakeFormationCollapseBroadPermissions(grantPermissions, schema.NewSet(schema.HashString, testCase.ConfiguredGrantPermissions))
+
+			if !reflect.DeepEqual(permissions, testCase.ExpectedPermissions) {
+				t.Errorf("expected permissions %v, got %v", testCase.ExpectedPermissions, permissions)
+			}
+
+			if !reflect.DeepEqual(grantPermissions, testCase.ExpectedGrantPermissions) {
+				t.Errorf("expected permissions with grant option %v, got %v", testCase.ExpectedGrantPermissions, grantPermissions)
+			}
+		})
+	}
+}
+
+func TestLakeFormationCatalogRevokeAllowed(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		CatalogResource    bool
+		AllowCatalogRevoke bool
+		ExpectedError      bool
+	}{
+		{
+			Name:            "catalog without confirmation",
+			CatalogResource: true,
+			ExpectedError:   true,
+		},
+		{
+			Name:               "catalog with confirmation",
+			CatalogResource:    true,
+			AllowCatalogRevoke: true,
+		},
+		{
+			Name: "other resource",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := lakeFormationCatalogRevokeAllowed(testCase.CatalogResource, testCase.AllowCatalogRevoke)
+
+			if got := err != nil; got != testCase.ExpectedError {
+				t.Errorf("expected error %t, got %v", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestFlattenLakeFormationPermissionsDiff(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Expected        []string
+		Reported        []string
+		ExpectedExtra   []interface{}
+		ExpectedMissing []interface{}
+	}{
+		{
+			Name:            "in sync",
+			Expected:        []string{lakeformation.PermissionAlter, lakeformation.PermissionDrop},
+			Reported:        []string{lakeformation.PermissionDrop, lakeformation.PermissionAlter},
+			ExpectedExtra:   []interface{}{},
+			ExpectedMissing: []interface{}{},
+		},
+		{
+			// DROP was revoked and INSERT and DELETE were granted outside of Terraform.
+			Name:            "drifted",
+			Expected:        []string{lakeformation.PermissionAlter, lakeformation.PermissionDrop},
+			Reported:        []string{lakeformation.PermissionInsert, lakeformation.PermissionAlter, lakeformation.PermissionDelete},
+			ExpectedExtra:   []interface{}{lakeformation.PermissionDelete, lakeformation.PermissionInsert},
+			ExpectedMissing: []interface{}{lakeformation.PermissionDrop},
+		},
+		{
+			Name:            "revoked",
+			Expected:        []string{lakeformation.PermissionSelect},
+			ExpectedExtra:   []interface{}{},
+			ExpectedMissing: []interface{}{lakeformation.PermissionSelect},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := flattenLakeFormationPermissionsDiff(testCase.Expected, testCase.Reported)
+
+			if !reflect.DeepEqual(got["extra_in_aws"], testCase.ExpectedExtra) {
+				t.Errorf("expected extra_in_aws %v, got %v", testCase.ExpectedExtra, got["extra_in_aws"])
+			}
+
+			if !reflect.DeepEqual(got["missing_in_aws"], testCase.ExpectedMissing) {
+				t.Errorf("expected missing_in_aws %v, got %v", testCase.ExpectedMissing, got["missing_in_aws"])
+			}
+		})
+	}
+}
+
+func TestLakeFormationValidateResourceBlocks(t *testing.T) {
+	block := []interface{}{map[string]interface{}{"name": "test"}}
+
+	testCases := []struct {
+		Name             string
+		CatalogResource  bool
+		DataLocation     []interface{}
+		Database         []interface{}
+		Databases        []interface{}
+		Table            []interface{}
+		TableWithColumns []interface{}
+		ExpectError      bool
+	}{
+		{
+			Name:        "none",
+			ExpectError: true,
+		},
+		{
+			Name:            "catalog resource",
+			CatalogResource: true,
+		},
+		{
+			Name:         "data location",
+			DataLocation: block,
+		},
+		{
+			Name:     "database",
+			Database: block,
+		},
+		{
+			Name:      "databases",
+			Databases: []interface{}{map[string]interface{}{"name": "test"}, map[string]interface{}{"name": "other"}},
+		},
+		{
+			Name:  "table",
+			Table: block,
+		},
+		{
+			Name:             "table with columns",
+			TableWithColumns: block,
+		},
+		{
+			Name:            "catalog resource and database",
+			CatalogResource: true,
+			Database:        block,
+			ExpectError:     true,
+		},
+		{
+			Name:        "database and databases",
+			Database:    block,
+			Databases:   block,
+			ExpectError: true,
+		},
+		{
+			Name:             "table and table with columns",
+			Table:            block,
+			TableWithColumns: block,
+			ExpectError:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := lakeFormationValidateResourceBlocks(testCase.CatalogResource, testCase.DataLocation, testCase.Database, testCase.Databases, testCase.Table, testCase.TableWithColumns)
+
+			if testCase.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsDatabasesGranted(t *testing.T) {
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	databases := []interface{}{
+		map[string]interface{}{"catalog_id": "", "name": "granted"},
+		map[string]interface{}{"catalog_id": "", "name": "revoked"},
+		map[string]interface{}{"catalog_id": "", "name": "partial"},
+		map[string]interface{}{"catalog_id": "", "name": "all"},
+		map[string]interface{}{"catalog_id": "210987654321", "name": "other_catalog"},
+	}
+
+	// Entries for the databases listed in a single ListPermissions call, in no particular order.
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionCreateTable}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("granted"),
+				},
+			},
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("partial"),
+				},
+			},
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAll}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("all"),
+				},
+			},
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionCreateTable}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("other_catalog"),
+				},
+			},
+		},
+	}
+
+	permissions := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionAlter, lakeformation.PermissionCreateTable})
+	permissionsWithGrantOption := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionCreateTable})
+
+	granted, missing := lakeFormationPermissionsDatabasesGranted("test", databases, "123456789012", permissions, permissionsWithGrantOption, entries)
+
+	if expected := []interface{}{databases[0], databases[3]}; !reflect.DeepEqual(granted, expected) {
+		t.Errorf("expected granted databases %v, got %v", expected, granted)
+	}
+
+	if expected := []string{"revoked", "partial", "other_catalog"}; !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing databases %v, got %v", expected, missing)
+	}
+}
+
+func TestExpandLakeFormationPermissionsDatabasesEntries(t *testing.T) {
+	input := &lakeformation.GrantPermissionsInput{
+		Permissions:                aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+		PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+		},
+	}
+
+	// The same database name in two catalogs must not share an entry ID.
+	got := expandLakeFormationPermissionsDatabasesEntries(input, []interface{}{
+		map[string]interface{}{"catalog_id": "", "name": "db"},
+		map[string]interface{}{"catalog_id": "210987654321", "name": "db"},
+	}, "123456789012")
+
+	expected := []*lakeformation.BatchPermissionsRequestEntry{
+		{
+			Id:                         aws.String("DATABASE,123456789012,db"),
+			Permissions:                input.Permissions,
+			PermissionsWithGrantOption: input.PermissionsWithGrantOption,
+			Principal:                  input.Principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+		},
+		{
+			Id:                         aws.String("DATABASE,210987654321,db"),
+			Permissions:                input.Permissions,
+			PermissionsWithGrantOption: input.PermissionsWithGrantOption,
+			Principal:                  input.Principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("210987654321"),
+					Name:      aws.String("db"),
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected entries %v, got %v", expected, got)
+	}
+
+	// Batch failures are reported per database.
+	err := lakeFormationBatchPermissionsFailuresError([]*lakeformation.BatchPermissionsFailureEntry{
+		{
+			Error: &lakeformation.ErrorDetail{
+				ErrorCode:    aws.String(lakeformation.ErrCodeEntityNotFoundException),
+				ErrorMessage: aws.String("Database not found"),
+			},
+			RequestEntry: got[1],
+		},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "entry (DATABASE,210987654321,db): EntityNotFoundException: Database not found") {
+		t.Errorf("expected failure naming the database, got %v", err)
+	}
+}
+
+func TestLakeFormationCatalogIdValidation(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"aws_lakeformation_batch_permissions":            resourceAwsLakeFormationBatchPermissions(),
+		"aws_lakeformation_data_lake_settings":           resourceAwsLakeFormationDataLakeSettings(),
+		"aws_lakeformation_database_default_permissions": resourceAwsLakeFormationDatabaseDefaultPermissions(),
+		"aws_lakeformation_permissions":                  resourceAwsLakeFormationPermissions(),
+		"data.aws_lakeformation_data_lake_settings":      dataSourceAwsLakeFormationDataLakeSettings(),
+		"data.aws_lakeformation_permissions":             dataSourceAwsLakeFormationPermissions(),
+		"data.aws_lakeformation_principal_permissions":   dataSourceAwsLakeFormationPrincipalPermissions(),
+	}
+
+	for name, r := range resources {
+		for _, path := range lakeFormationConfigurableCatalogIdPaths("", r.Schema) {
+			v := lakeFormationSchemaAtPath(r.Schema, path)
+
+			if v.ValidateFunc == nil {
+				t.Errorf("%s: %s is not validated", name, path)
+				continue
+			}
+
+			if _, errs := v.ValidateFunc("123456789012", path); len(errs) > 0 {
+				t.Errorf("%s: %s rejected an account ID: %v", name, path, errs)
+			}
+
+			// Catalog IDs are numeric account IDs; an account alias would never match the catalog ID reported by AWS.
+			if _, errs := v.ValidateFunc("example-alias", path); len(errs) == 0 {
+				t.Errorf("%s: %s accepted an account alias", name, path)
+			}
+		}
+	}
+}
+
+// lakeFormationConfigurableCatalogIdPaths returns the paths of the catalog_id attributes that can be configured.
+func lakeFormationConfigurableCatalogIdPaths(prefix string, m map[string]*schema.Schema) []string {
+	var paths []string
+
+	for k, v := range m {
+		if k == "catalog_id" && (v.Optional || v.Required) {
+			paths = append(paths, prefix+k)
+		}
+
+		if elem, ok := v.Elem.(*schema.Resource); ok && (v.Optional || v.Required) {
+			paths = append(paths, lakeFormationConfigurableCatalogIdPaths(prefix+k+".0.", elem.Schema)...)
+		}
+	}
+
+	return paths
+}
+
+func lakeFormationSchemaAtPath(m map[string]*schema.Schema, path string) *schema.Schema {
+	parts := strings.SplitN(path, ".0.", 2)
+
+	if len(parts) == 1 {
+		return m[parts[0]]
+	}
+
+	return lakeFormationSchemaAtPath(m[parts[0]].Elem.(*schema.Resource).Schema, parts[1])
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_catalogAdmins(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Catalog: &lakeformation.CatalogResource{},
+	}
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+
+	// Each data lake admin that granted on the catalog can surface its own entry for the principal.
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionCreateDatabase}),
+			Principal:   principal,
+			Resource:    &lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}},
+		},
+		{
+			AdditionalDetails:          &lakeformation.DetailsMap{},
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionCreateDatabase}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionCreateDatabase}),
+			Principal:                  principal,
+			Resource:                   &lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDataLocationAccess}),
+			Principal:   principal,
+			Resource:    &lakeformation.Resource{Catalog: &lakeformation.CatalogResource{}},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	if len(collector.matches) != 3 {
+		t.Fatalf("expected the 3 catalog entries to match, got %d", len(collector.matches))
+	}
+
+	aggregated := tflakeformation.PermissionsAggregate(collector.matches)
+
+	if len(aggregated) != 1 {
+		t.Fatalf("expected a single aggregated entry, got %d: %v", len(aggregated), aggregated)
+	}
+
+	if got, expected := flattenLakeFormationPermissions(aggregated), []string{lakeformation.PermissionCreateDatabase, lakeformation.PermissionDataLocationAccess}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	if got, expected := flattenLakeFormationGrantPermissions(aggregated), []string{lakeformation.PermissionCreateDatabase}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions with grant option %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_databaseImplicitTables(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			Name: aws.String("db"),
+		},
+	}
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+
+	// A database grant can be listed together with implicit entries for the tables in the database.
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("db"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("db"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("db"),
+				},
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	if len(collector.matches) != 1 || collector.matches[0].Resource.Database == nil {
+		t.Fatalf("expected only the database entry to match, got %v", collector.matches)
+	}
+
+	if got, expected := flattenLakeFormationPermissions(collector.matches), []string{lakeformation.PermissionCreateTable}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatchTarget_sharedDatabaseAlias(t *testing.T) {
+	consumerCatalogId := "123456789012"
+	ownerCatalogId := "111122223333"
+
+	matchResource := &lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			Name: aws.String("alias"),
+		},
+	}
+	// The resource link in the consumer account refers to the database shared by the owner account. The target is
+	// looked up on create and kept in target_database, from which reads get it.
+	targetResource := expandLakeFormationDatabaseTarget(flattenLakeFormationDatabaseTarget(lakeFormationDatabaseTargetResource(&glue.Database{
+		CatalogId: aws.String(consumerCatalogId),
+		Name:      aws.String("alias"),
+		TargetDatabase: &glue.DatabaseIdentifier{
+			CatalogId:    aws.String(ownerCatalogId),
+			DatabaseName: aws.String("shared"),
+		},
+	})))
+
+	database := func(catalogId, name string, permission string) *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{permission}),
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+			},
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String(catalogId),
+					Name:      aws.String(name),
+				},
+			},
+		}
+	}
+
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		database(consumerCatalogId, "alias", lakeformation.PermissionDescribe),
+		database(ownerCatalogId, "shared", lakeformation.PermissionCreateTable),
+		database(ownerCatalogId, "other", lakeformation.PermissionAlter),
+		database(consumerCatalogId, "shared", lakeformation.PermissionDrop),
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		if apiObject := resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, consumerCatalogId, permission); apiObject != nil {
+			return apiObject
+		}
+
+		return resourceAwsLakeFormationPermissionsMatchTarget("test", matchResource, targetResource, consumerCatalogId, permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	aggregated := tflakeformation.PermissionsAggregate(collector.matches)
+
+	if len(aggregated) != 1 {
+		t.Fatalf("expected a single aggregated entry, got %d: %v", len(aggregated), aggregated)
+	}
+
+	expectedDatabase := &lakeformation.DatabaseResource{
+		CatalogId: aws.String(consumerCatalogId),
+		Name:      aws.String("alias"),
+	}
+
+	if got := aggregated[0].Resource.Database; !reflect.DeepEqual(got, expectedDatabase) {
+		t.Errorf("expected database %v, got %v", expectedDatabase, got)
+	}
+
+	if got, expected := flattenLakeFormationPermissions(aggregated), []string{lakeformation.PermissionDescribe, lakeformation.PermissionCreateTable}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	if got := lakeFormationDatabaseTargetResource(&glue.Database{Name: aws.String("db")}); got != nil {
+		t.Errorf("expected no target for a database that is not a resource link, got %v", got)
+	}
+
+	if got := expandLakeFormationDatabaseTarget(flattenLakeFormationDatabaseTarget(nil)); got != nil {
+		t.Errorf("expected no target from an empty target_database, got %v", got)
+	}
+}
+
+func TestLakeFormationResourceWithConfigColumnCase(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			CatalogId:    aws.String("123456789012"),
+			ColumnNames:  aws.StringSlice([]string{"EventTime", "UserId"}),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	// The catalog engine lowercased the column names of the grant.
+	entry := func() *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:    aws.String("123456789012"),
+					ColumnNames:  aws.StringSlice([]string{"userid", "eventtime"}),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		}
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", entry()) != nil {
+		t.Error("expected lowercased column names not to match by default")
+	}
+
+	permission := entry()
+	permission.Resource = lakeFormationResourceWithConfigColumnCase(matchResource, permission.Resource)
+
+	apiObject := resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+
+	if apiObject == nil {
+		t.Fatal("expected lowercased column names to match when ignoring case")
+	}
+
+	if got, expected := flattenLakeFormationTableWithColumnsResource(apiObject.TableWithColumns)["column_names"], []interface{}{"UserId", "EventTime"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected column names %v, got %v", expected, got)
+	}
+
+	other := entry()
+	other.Resource.TableWithColumns.ColumnNames = aws.StringSlice([]string{"userid", "sessionid"})
+	other.Resource = lakeFormationResourceWithConfigColumnCase(matchResource, other.Resource)
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", other) != nil {
+		t.Error("expected different column names not to match when ignoring case")
+	}
+}
+
+func TestLakeFormationResourceWithConfigTableNameCase(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("EventLog"),
+		},
+	}
+	// The catalog lowercased the table name of the grant.
+	entry := func() *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("eventlog"),
+				},
+			},
+		}
+	}
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", entry()) != nil {
+		t.Error("expected a lowercased table name not to match by default")
+	}
+
+	permission := entry()
+	permission.Resource = lakeFormationResourceWithConfigTableNameCase(matchResource, permission.Resource)
+
+	apiObject := resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission)
+
+	if apiObject == nil {
+		t.Fatal("expected a lowercased table name to match when ignoring case")
+	}
+
+	if got, expected := flattenLakeFormationTableResource(apiObject.Table)["name"], "EventLog"; got != expected {
+		t.Errorf("expected table name %q, got %q", expected, got)
+	}
+
+	// The SELECT companion entry is reconciled with the configured table too.
+	selectPermissionsResource := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			CatalogId:      aws.String("123456789012"),
+			ColumnWildcard: &lakeformation.ColumnWildcard{},
+			DatabaseName:   aws.String("db"),
+			Name:           aws.String("EventLog"),
+		},
+	}
+	companion := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Resource: &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:      aws.String("123456789012"),
+				ColumnWildcard: &lakeformation.ColumnWildcard{},
+				DatabaseName:   aws.String("db"),
+				Name:           aws.String("eventlog"),
+			},
+		},
+	}
+	companion.Resource = lakeFormationResourceWithConfigTableNameCase(matchResource, companion.Resource)
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, selectPermissionsResource, "123456789012", companion) == nil {
+		t.Error("expected a lowercased SELECT companion to match when ignoring case")
+	}
+
+	other := entry()
+	other.Resource.Table.Name = aws.String("eventlog_archive")
+	other.Resource = lakeFormationResourceWithConfigTableNameCase(matchResource, other.Resource)
+
+	if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", other) != nil {
+		t.Error("expected a different table name not to match when ignoring case")
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_crossAccountVersions(t *testing.T) {
+	ownerCatalogId := "111122223333"
+	sharerCatalogId := "123456789012"
+
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String(ownerCatalogId),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	entry := func(catalogId string, resourceShare string, permissions ...string) *lakeformation.PrincipalResourcePermissions {
+		return &lakeformation.PrincipalResourcePermissions{
+			AdditionalDetails: &lakeformation.DetailsMap{
+				ResourceShare: aws.StringSlice([]string{resourceShare}),
+			},
+			Permissions: aws.StringSlice(permissions),
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String("444455556666"),
+			},
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String(catalogId),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name    string
+		Entries []*lakeformation.PrincipalResourcePermissions
+	}{
+		{
+			// Version 1 creates a resource share per grant, and the grants can echo either catalog ID.
+			Name: "version 1",
+			Entries: []*lakeformation.PrincipalResourcePermissions{
+				entry(ownerCatalogId, "arn:aws:ram:us-west-2:111122223333:resource-share/share-1", lakeformation.PermissionSelect),    //lintignore:AWSAT003,AWSAT005
+				entry(sharerCatalogId, "arn:aws:ram:us-west-2:111122223333:resource-share/share-2", lakeformation.PermissionDescribe), //lintignore:AWSAT003,AWSAT005
+			},
+		},
+		{
+			// Version 3 reports the grant once, through a single resource share for the account.
+			Name: "version 3",
+			Entries: []*lakeformation.PrincipalResourcePermissions{
+				entry(ownerCatalogId, "arn:aws:ram:us-west-2:111122223333:resource-share/LakeFormation-V3-share", lakeformation.PermissionDescribe, lakeformation.PermissionSelect), //lintignore:AWSAT003,AWSAT005
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+				return resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, sharerCatalogId, permission)
+			})
+			collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: testCase.Entries}, true)
+
+			aggregated := tflakeformation.PermissionsAggregate(collector.matches)
+
+			if len(aggregated) != 1 {
+				t.Fatalf("expected a single aggregated entry, got %d: %v", len(aggregated), aggregated)
+			}
+
+			if got := aws.StringValue(aggregated[0].Resource.Table.CatalogId); got != ownerCatalogId {
+				t.Errorf("expected catalog ID %s, got %s", ownerCatalogId, got)
+			}
+
+			// Every resource share is kept so that the cross-account status covers all of them.
+			if got := len(aggregated[0].AdditionalDetails.ResourceShare); got != len(testCase.Entries) {
+				t.Errorf("expected %d resource shares, got %d", len(testCase.Entries), got)
+			}
+
+			got := flattenLakeFormationPermissions(aggregated)
+			sort.Strings(got)
+
+			if expected := []string{lakeformation.PermissionDescribe, lakeformation.PermissionSelect}; !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected permissions %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationIamPrincipalWarning(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Principal       string
+		LookupErr       error
+		ExpectedLookup  string
+		ExpectedWarning bool
+	}{
+		{
+			Name:           "existing role",
+			Principal:      "arn:aws:iam::123456789012:role/service-role/test", //lintignore:AWSAT003,AWSAT005
+			ExpectedLookup: "role test",
+		},
+		{
+			Name:            "nonexistent role",
+			Principal:       "arn:aws:iam::123456789012:role/tset", //lintignore:AWSAT003,AWSAT005
+			LookupErr:       awserr.New(iam.ErrCodeNoSuchEntityException, "The role with name tset cannot be found.", nil),
+			ExpectedLookup:  "role tset",
+			ExpectedWarning: true,
+		},
+		{
+			Name:            "nonexistent user",
+			Principal:       "arn:aws:iam::123456789012:user/test", //lintignore:AWSAT003,AWSAT005
+			LookupErr:       awserr.New(iam.ErrCodeNoSuchEntityException, "The user with name test cannot be found.", nil),
+			ExpectedLookup:  "user test",
+			ExpectedWarning: true,
+		},
+		{
+			Name:           "access denied",
+			Principal:      "arn:aws:iam::123456789012:role/test", //lintignore:AWSAT003,AWSAT005
+			LookupErr:      awserr.New("AccessDenied", "User is not authorized to perform: iam:GetRole", nil),
+			ExpectedLookup: "role test",
+		},
+		{
+			Name:      "group",
+			Principal: "arn:aws:iam::123456789012:group/test", //lintignore:AWSAT003,AWSAT005
+		},
+		{
+			Name:      "account",
+			Principal: "123456789012",
+		},
+		{
+			Name:      "SAML provider",
+			Principal: "arn:aws:iam::123456789012:saml-provider/test", //lintignore:AWSAT003,AWSAT005
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var lookup string
+
+			warning := lakeFormationIamPrincipalWarning(testCase.Principal, func(principalType, name string) error {
+				lookup = principalType + " " + name
+				return testCase.LookupErr
+			})
+
+			if lookup != testCase.ExpectedLookup {
+				t.Errorf("expected lookup %q, got %q", testCase.ExpectedLookup, lookup)
+			}
+
+			if got := warning != ""; got != testCase.ExpectedWarning {
+				t.Errorf("expected warning %t, got %q", testCase.ExpectedWarning, warning)
+			}
+		})
+	}
+}
+
+func TestLakeFormationGrantFeasibilityIssues(t *testing.T) {
+	notFound := awserr.New(lakeformation.ErrCodeEntityNotFoundException, "Entity not found", nil)
+	accessDenied := awserr.New("AccessDeniedException", "User is not authorized to perform: glue:GetTable", nil)
+
+	table := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	dataLocation := &lakeformation.Resource{
+		DataLocation: &lakeformation.DataLocationResource{
+			CatalogId:   aws.String("123456789012"),
+			ResourceArn: aws.String("arn:aws:s3:::bucket/data"), //lintignore:AWSAT005
+		},
+	}
+
+	testCases := []struct {
+		Name                 string
+		Resource             *lakeformation.Resource
+		RegisterDataLocation bool
+		PrincipalErr         error
+		LookupErr            error
+		ExpectedLookup       string
+		ExpectedIssues       int
+	}{
+		{
+			Name:           "feasible",
+			Resource:       table,
+			ExpectedLookup: "table 123456789012 db tbl",
+		},
+		{
+			Name:           "principal not found",
+			Resource:       table,
+			PrincipalErr:   awserr.New(iam.ErrCodeNoSuchEntityException, "The role with name test cannot be found.", nil),
+			ExpectedLookup: "table 123456789012 db tbl",
+			ExpectedIssues: 1,
+		},
+		{
+			Name:           "table not found",
+			Resource:       table,
+			LookupErr:      notFound,
+			ExpectedLookup: "table 123456789012 db tbl",
+			ExpectedIssues: 1,
+		},
+		{
+			Name: "database of table wildcard not found",
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("db"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+			LookupErr:      notFound,
+			ExpectedLookup: "database 123456789012 db",
+			ExpectedIssues: 1,
+		},
+		{
+			Name:           "table lookup denied",
+			Resource:       table,
+			LookupErr:      accessDenied,
+			ExpectedLookup: "table 123456789012 db tbl",
+		},
+		{
+			Name:           "data location not registered",
+			Resource:       dataLocation,
+			LookupErr:      notFound,
+			ExpectedLookup: "data location arn:aws:s3:::bucket/data", //lintignore:AWSAT005
+			ExpectedIssues: 1,
+		},
+		{
+			Name:                 "data location registered when granting",
+			Resource:             dataLocation,
+			RegisterDataLocation: true,
+			LookupErr:            notFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var lookup string
+
+			checks := lakeFormationGrantFeasibilityChecks{
+				dataLocation: func(resourceArn string) error {
+					lookup = "data location " + resourceArn
+					return testCase.LookupErr
+				},
+				database: func(catalogId, name string) error {
+					lookup = "database " + catalogId + " " + name
+					return testCase.LookupErr
+				},
+				principal: func(principalType, name string) error {
+					return testCase.PrincipalErr
+				},
+				table: func(catalogId, databaseName, name string) error {
+					lookup = "table " + catalogId + " " + databaseName + " " + name
+					return testCase.LookupErr
+				},
+			}
+
+			issues := lakeFormationGrantFeasibilityIssues("arn:aws:iam::123456789012:role/test", testCase.Resource, testCase.RegisterDataLocation, checks) //lintignore:AWSAT003,AWSAT005
+
+			if lookup != testCase.ExpectedLookup {
+				t.Errorf("expected lookup %q, got %q", testCase.ExpectedLookup, lookup)
+			}
+
+			if len(issues) != testCase.ExpectedIssues {
+				t.Errorf("expected %d issues, got %v", testCase.ExpectedIssues, issues)
+			}
+		})
+	}
+}
+
+func TestLakeFormationResourceWithEffectiveCatalogId_preMigration(t *testing.T) {
+	// Grants made in IAM-only mode are listed with an empty catalog ID and the broad permission alongside the individual ones.
+	in := lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	out := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAll, lakeformation.PermissionAlter, lakeformation.PermissionDescribe}),
+		Principal: &lakeformation.DataLakePrincipal{
+			DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+		},
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    aws.String(""),
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("tbl"),
+			},
+		},
+	}
+
+	normalized := tflakeformation.ResourceWithEffectiveCatalogID(*out.Resource, "123456789012")
+
+	if !tflakeformation.ResourceEqual(in, normalized) {
+		t.Fatalf("expected pre-migration grant to match configuration, got %v", normalized)
+	}
+
+	configured := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionAll})
+	got := lakeFormationCollapseBroadPermissions(flattenLakeFormationPermissions([]*lakeformation.PrincipalResourcePermissions{out}), configured)
+
+	if expected := []string{lakeformation.PermissionAll}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_defaultDatabase(t *testing.T) {
+	// The Glue "default" database, created automatically in some catalogs, is an ordinary database name here.
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	database := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionCreateTable}),
+		Principal:   principal,
+		Resource: &lakeformation.Resource{
+			Database: &lakeformation.DatabaseResource{
+				CatalogId: aws.String("123456789012"),
+				Name:      aws.String("default"),
+			},
+		},
+	}
+	table := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Principal:   principal,
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    aws.String("123456789012"),
+				DatabaseName: aws.String("default"),
+				Name:         aws.String("tbl"),
+			},
+		},
+	}
+	other := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+		Principal:   principal,
+		Resource: &lakeformation.Resource{
+			Database: &lakeformation.DatabaseResource{
+				CatalogId: aws.String("123456789012"),
+				Name:      aws.String("db"),
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name          string
+		MatchResource *lakeformation.Resource
+		Expected      *lakeformation.PrincipalResourcePermissions
+	}{
+		{
+			Name: "database",
+			MatchResource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("default"),
+				},
+			},
+			Expected: database,
+		},
+		{
+			Name: "table in database",
+			MatchResource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("default"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			Expected: table,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+				return resourceAwsLakeFormationPermissionsMatch("test", testCase.MatchResource, nil, "123456789012", permission)
+			})
+			collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: []*lakeformation.PrincipalResourcePermissions{database, table, other}}, true)
+
+			if len(collector.matches) != 1 || collector.matches[0] != testCase.Expected {
+				t.Fatalf("expected only %v to match, got %v", testCase.Expected, collector.matches)
+			}
+		})
+	}
+
+	principalId, _, _, apiObjects, err := tflakeformation.PermissionsParseID("arn:aws:iam::123456789012:role/test,DATABASE,,default") //lintignore:AWSAT003,AWSAT005
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := aws.StringValue(principal.DataLakePrincipalIdentifier); principalId != expected {
+		t.Errorf("expected principal %q, got %q", expected, principalId)
+	}
+
+	if len(apiObjects) != 1 || apiObjects[0].Database == nil || aws.StringValue(apiObjects[0].Database.Name) != "default" {
+		t.Errorf("expected the default database, got %v", apiObjects)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_nonDefaultCatalog(t *testing.T) {
+	// The grant is made in catalog 444455556666, set by the top-level catalog_id, by a caller in account 123456789012.
+	catalogId := "444455556666"
+
+	testCases := []struct {
+		Name          string
+		MatchResource *lakeformation.Resource
+		Listed        *lakeformation.Resource
+		Expected      bool
+	}{
+		{
+			Name: "catalog ID omitted by configuration and response",
+			MatchResource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			Listed: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "catalog ID omitted by configuration",
+			MatchResource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+			Listed: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String(catalogId),
+					Name:      aws.String("db"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "catalog ID omitted by response",
+			MatchResource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String(catalogId),
+					Name:      aws.String("db"),
+				},
+			},
+			Listed: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "caller account catalog",
+			MatchResource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String(catalogId),
+					Name:      aws.String("db"),
+				},
+			},
+			Listed: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			permission := &lakeformation.PrincipalResourcePermissions{
+				Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+				Resource:    testCase.Listed,
+			}
+
+			if got := resourceAwsLakeFormationPermissionsMatch("test", testCase.MatchResource, nil, catalogId, permission) != nil; got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+
+	// Glue lookups for the configured resource, such as resource link targets, use the catalog of the grant.
+	lookupResource := tflakeformation.ResourceWithEffectiveCatalogID(lakeformation.Resource{
+		Database: &lakeformation.DatabaseResource{
+			Name: aws.String("db"),
+		},
+	}, catalogId)
+
+	if got := aws.StringValue(lookupResource.Database.CatalogId); got != catalogId {
+		t.Errorf("expected lookup catalog ID %s, got %s", catalogId, got)
+	}
+}
+
+func TestLakeFormationPermissionsCrossAccountStatus(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Associations []*ram.ResourceShareAssociation
+		Expected     string
+	}{
+		{
+			Name: "not shared",
+		},
+		{
+			Name: "accepted",
+			Associations: []*ram.ResourceShareAssociation{
+				{
+					AssociatedEntity: aws.String("111122223333"),
+					Status:           aws.String(ram.ResourceShareAssociationStatusAssociated),
+				},
+			},
+			Expected: lakeFormationCrossAccountStatusActive,
+		},
+		{
+			Name: "pending acceptance",
+			Associations: []*ram.ResourceShareAssociation{
+				{
+					AssociatedEntity: aws.String("111122223333"),
+					Status:           aws.String(ram.ResourceShareAssociationStatusAssociated),
+				},
+				{
+					AssociatedEntity: aws.String("111122223333"),
+					Status:           aws.String(ram.ResourceShareAssociationStatusAssociating),
+				},
+			},
+			Expected: lakeFormationCrossAccountStatusPendingAcceptance,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := lakeFormationPermissionsCrossAccountStatus(testCase.Associations)
+
+			if got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationPermissionsAnyResourceShared(t *testing.T) {
+	entry := func(resourceShareArns ...string) *lakeformation.PrincipalResourcePermissions {
+		apiObject := &lakeformation.PrincipalResourcePermissions{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+		}
+
+		if resourceShareArns != nil {
+			apiObject.AdditionalDetails = &lakeformation.DetailsMap{
+				ResourceShare: aws.StringSlice(resourceShareArns),
+			}
+		}
+
+		return apiObject
+	}
+
+	testCases := []struct {
+		Name       string
+		ApiObjects []*lakeformation.PrincipalResourcePermissions
+		Expected   bool
+	}{
+		{
+			Name: "no entries",
+		},
+		{
+			Name:       "not shared",
+			ApiObjects: []*lakeformation.PrincipalResourcePermissions{entry(), entry([]string{}...)},
+		},
+		{
+			Name:       "shared",
+			ApiObjects: []*lakeformation.PrincipalResourcePermissions{entry(), entry("arn:aws:ram:us-west-2:123456789012:resource-share/test")}, //lintignore:AWSAT003,AWSAT005
+			Expected:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := lakeFormationPermissionsAnyResourceShared(testCase.ApiObjects); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestExpandLakeFormationRevokeAllPermissionsInputs(t *testing.T) {
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	table := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+		},
+	}
+	tableWithColumns := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			CatalogId:      aws.String("123456789012"),
+			ColumnWildcard: &lakeformation.ColumnWildcard{},
+			DatabaseName:   aws.String("db"),
+			Name:           aws.String("table"),
+		},
+	}
+
+	// Several grants, some made outside of Terraform, exist for the principal on the table.
+	seeded := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Principal:   principal,
+			Resource:    table,
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionDrop}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionDrop}),
+			Principal:                  principal,
+			Resource:                   table,
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:   principal,
+			Resource:    tableWithColumns,
+		},
+		{
+			Principal: principal,
+			Resource:  table,
+		},
+	}
+
+	testCases := []struct {
+		Name     string
+		Resource *lakeformation.Resource
+		Expected []*lakeformation.PrincipalResourcePermissions
+	}{
+		{
+			Name:     "table",
+			Resource: table,
+			Expected: seeded[0:2],
+		},
+		{
+			Name:     "table without catalog ID",
+			Resource: &lakeformation.Resource{Table: &lakeformation.TableResource{DatabaseName: aws.String("db"), Name: aws.String("table")}},
+			Expected: seeded[0:2],
+		},
+		{
+			Name:     "table with columns",
+			Resource: tableWithColumns,
+			Expected: seeded[2:3],
+		},
+		{
+			Name:     "other table",
+			Resource: &lakeformation.Resource{Table: &lakeformation.TableResource{DatabaseName: aws.String("db"), Name: aws.String("other")}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := expandLakeFormationRevokeAllPermissionsInputs(aws.String("123456789012"), "123456789012", testCase.Resource, seeded)
+
+			if len(got) != len(testCase.Expected) {
+				t.Fatalf("expected %d revoke requests, got %d", len(testCase.Expected), len(got))
+			}
+
+			for i, input := range got {
+				expected := testCase.Expected[i]
+
+				if aws.StringValue(input.CatalogId) != "123456789012" {
+					t.Errorf("request %d: expected catalog ID 123456789012, got %s", i, aws.StringValue(input.CatalogId))
+				}
+
+				if !reflect.DeepEqual(input.Permissions, expected.Permissions) {
+					t.Errorf("request %d: expected permissions %v, got %v", i, aws.StringValueSlice(expected.Permissions), aws.StringValueSlice(input.Permissions))
+				}
+
+				if !reflect.DeepEqual(input.PermissionsWithGrantOption, expected.PermissionsWithGrantOption) {
+					t.Errorf("request %d: expected permissions with grant option %v, got %v", i, aws.StringValueSlice(expected.PermissionsWithGrantOption), aws.StringValueSlice(input.PermissionsWithGrantOption))
+				}
+
+				if input.Resource != expected.Resource {
+					t.Errorf("request %d: expected resource %v, got %v", i, expected.Resource, input.Resource)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenLakeFormationPermissions_overlappingGrantOption(t *testing.T) {
+	input := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionDrop}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionDescribe}),
+		},
+		{
+			Permissions:                aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		},
+	}
+
+	if got, expected := flattenLakeFormationPermissions(input), []string{lakeformation.PermissionAlter, lakeformation.PermissionDrop}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	if got, expected := flattenLakeFormationGrantPermissions(input), []string{lakeformation.PermissionAlter, lakeformation.PermissionDescribe}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions with grant option %v, got %v", expected, got)
+	}
+
+	if got, expected := flattenLakeFormationPermissions([]*lakeformation.PrincipalResourcePermissions{{}}), []string{}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+}
+
+func TestFlattenLakeFormationPermissions_grantOptionOnly(t *testing.T) {
+	input := tflakeformation.PermissionsAggregate([]*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions:                []*string{},
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionAlter, lakeformation.PermissionDrop}),
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+			},
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String("db"),
+				},
+			},
+		},
+	})
+
+	configured := schema.NewSet(schema.HashString, []interface{}{lakeformation.PermissionAlter})
+	got := lakeFormationCollapseBroadPermissions(flattenLakeFormationPermissions(input), configured)
+
+	if got == nil || len(got) != 0 {
+		t.Errorf("expected empty permissions, got %#v", got)
+	}
+
+	if got, expected := flattenLakeFormationGrantPermissions(input), []string{lakeformation.PermissionAlter, lakeformation.PermissionDrop}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions with grant option %v, got %v", expected, got)
+	}
+}
+
+func TestFlattenLakeFormationNormalizedPermissions(t *testing.T) {
+	input := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect, lakeformation.PermissionAll}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("table"),
+				},
+			},
+		},
+	}
+
+	expected := []string{lakeformation.PermissionAll, lakeformation.PermissionSelect}
+	got := flattenLakeFormationNormalizedPermissions(input)
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestLakeFormationPermissionsHasCompanionSelect(t *testing.T) {
+	table := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("tbl"),
+			},
+		},
+	}
+	companion := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Resource: &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				ColumnWildcard: &lakeformation.ColumnWildcard{},
+				DatabaseName:   aws.String("db"),
+				Name:           aws.String("tbl"),
+			},
+		},
+	}
+	// SELECT was revoked, leaving only the grant option on the companion.
+	grantableCompanion := &lakeformation.PrincipalResourcePermissions{
+		PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Resource:                   companion.Resource,
+	}
+
+	testCases := []struct {
+		Name         string
+		ResourceType string
+		ApiObjects   []*lakeformation.PrincipalResourcePermissions
+		Expected     bool
+	}{
+		{
+			Name:         "table without SELECT",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{table},
+		},
+		{
+			Name:         "table SELECT",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{table, companion},
+			Expected:     true,
+		},
+		{
+			Name:         "table SELECT companion only",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{companion},
+			Expected:     true,
+		},
+		{
+			Name:         "table SELECT grantable only",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{table, grantableCompanion},
+		},
+		{
+			Name:         "table with columns",
+			ResourceType: DataLakeResourceTypeTableWithColumns,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{companion},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := lakeFormationPermissionsHasCompanionSelect(testCase.ResourceType, testCase.ApiObjects); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_grantableOnly(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("tbl"),
+		},
+	}
+	selectPermissionsResource := &lakeformation.Resource{
+		TableWithColumns: &lakeformation.TableWithColumnsResource{
+			ColumnWildcard: &lakeformation.ColumnWildcard{},
+			DatabaseName:   aws.String("db"),
+			Name:           aws.String("tbl"),
+		},
+	}
+	principal := &lakeformation.DataLakePrincipal{
+		DataLakePrincipalIdentifier: aws.String("arn:aws:iam::123456789012:role/test"), //lintignore:AWSAT003,AWSAT005
+	}
+	entries := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Principal:   principal,
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("tbl"),
+				},
+			},
+		},
+		// The principal can grant SELECT but no longer holds it.
+		{
+			PermissionsWithGrantOption: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Principal:                  principal,
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("tbl"),
+				},
+			},
+		},
+	}
+
+	collector := newLakeFormationPermissionsCollector(func(permission *lakeformation.PrincipalResourcePermissions) *lakeformation.Resource {
+		return resourceAwsLakeFormationPermissionsMatch("test", matchResource, selectPermissionsResource, "123456789012", permission)
+	})
+	collector.page(&lakeformation.ListPermissionsOutput{PrincipalResourcePermissions: entries}, true)
+
+	matches := tflakeformation.PermissionsAggregate(collector.matches)
+
+	if got, expected := flattenLakeFormationPermissions(matches), []string{lakeformation.PermissionAlter}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	if got, expected := flattenLakeFormationNormalizedPermissions(matches), []string{lakeformation.PermissionAlter}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected normalized permissions %v, got %v", expected, got)
+	}
+
+	if got, expected := flattenLakeFormationGrantPermissions(matches), []string{lakeformation.PermissionSelect}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected grant options %v, got %v", expected, got)
+	}
+
+	if lakeFormationPermissionsHasCompanionSelect(lakeformation.DataLakeResourceTypeTable, matches) {
+		t.Error("expected the grantable only companion not to be reported as a SELECT grant")
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsTableResource(t *testing.T) {
+	// Only the SELECT companion entry is returned for a table SELECT grant.
+	input := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+			Resource: &lakeformation.Resource{
+				TableWithColumns: &lakeformation.TableWithColumnsResource{
+					CatalogId:      aws.String("123456789012"),
+					ColumnWildcard: &lakeformation.ColumnWildcard{},
+					DatabaseName:   aws.String("db"),
+					Name:           aws.String("table"),
+				},
+			},
+		},
+	}
+
+	expected := &lakeformation.TableResource{
+		CatalogId:    aws.String("123456789012"),
+		DatabaseName: aws.String("db"),
+		Name:         aws.String("table"),
+	}
+	got := resourceAwsLakeFormationPermissionsTableResource(input)
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsTableResource_wildcard(t *testing.T) {
+	// A table wildcard grant is returned next to the implicit database context and any named table grants.
+	listed := []*lakeformation.PrincipalResourcePermissions{
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionDescribe}),
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					CatalogId: aws.String("123456789012"),
+					Name:      aws.String("db"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String("table"),
+				},
+			},
+		},
+		{
+			Permissions: aws.StringSlice([]string{lakeformation.PermissionAll}),
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("db"),
+					Name:          aws.String("ALL_TABLES"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+		},
+	}
+
+	var matched []*lakeformation.PrincipalResourcePermissions
+
+	for _, permission := range listed {
+		in := lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				DatabaseName:  aws.String("db"),
+				TableWildcard: &lakeformation.TableWildcard{},
+			},
+		}
+
+		if tflakeformation.ResourceEqual(in, *permission.Resource) {
+			matched = append(matched, permission)
+		}
+	}
+
+	if len(matched) != 1 {
+		t.Fatalf("expected 1 matched entry, got %d", len(matched))
+	}
+
+	if got, expected := flattenLakeFormationPermissions(matched), []string{lakeformation.PermissionAll}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected permissions %v, got %v", expected, got)
+	}
+
+	expected := map[string]interface{}{
+		"catalog_id":    "123456789012",
+		"database_name": "db",
+		"wildcard":      true,
+	}
+	got := flattenLakeFormationTableResource(resourceAwsLakeFormationPermissionsTableResource(matched))
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsTableResource_allTablesSentinel(t *testing.T) {
+	// An all-tables grant can be returned with only the sentinel table name and no TableWildcard.
+	out := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			CatalogId:    aws.String("123456789012"),
+			DatabaseName: aws.String("db"),
+			Name:         aws.String(tflakeformation.TableNameAllTables),
+		},
+	}
+
+	in := lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName:  aws.String("db"),
+			TableWildcard: &lakeformation.TableWildcard{},
+		},
+	}
+
+	if !tflakeformation.ResourceEqual(in, *out) {
+		t.Error("expected wildcard configuration to match the all-tables sentinel")
+	}
+
+	named := lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+		},
+	}
+
+	if tflakeformation.ResourceEqual(named, *out) {
+		t.Error("expected named table configuration not to match the all-tables sentinel")
+	}
+
+	expected := map[string]interface{}{
+		"catalog_id":    "123456789012",
+		"database_name": "db",
+		"wildcard":      true,
+	}
+	got := flattenLakeFormationTableResource(out.Table)
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestResourceAwsLakeFormationPermissionsMatch_wildcardForNamedTable(t *testing.T) {
+	matchResource := &lakeformation.Resource{
+		Table: &lakeformation.TableResource{
+			DatabaseName: aws.String("db"),
+			Name:         aws.String("table"),
+		},
+	}
+
+	testCases := []struct {
+		Name     string
+		Resource *lakeformation.Resource
+		Covers   bool
+	}{
+		{
+			Name: "table wildcard",
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("db"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+			Covers: true,
+		},
+		{
+			Name: "all tables placeholder name",
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:    aws.String("123456789012"),
+					DatabaseName: aws.String("db"),
+					Name:         aws.String(tflakeformation.TableNameAllTables),
+				},
+			},
+			Covers: true,
+		},
+		{
+			Name: "table wildcard in other database",
+			Resource: &lakeformation.Resource{
+				Table: &lakeformation.TableResource{
+					CatalogId:     aws.String("123456789012"),
+					DatabaseName:  aws.String("other"),
+					TableWildcard: &lakeformation.TableWildcard{},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			permission := &lakeformation.PrincipalResourcePermissions{
+				Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+				Resource:    testCase.Resource,
+			}
+
+			if resourceAwsLakeFormationPermissionsMatch("test", matchResource, nil, "123456789012", permission) != nil {
+				t.Error("expected a wildcard grant not to match a named table configuration")
+			}
+
+			if got := tflakeformation.WildcardTableCovers(matchResource, testCase.Resource); got != testCase.Covers {
+				t.Errorf("expected covers %t, got %t", testCase.Covers, got)
+			}
+		})
+	}
+}
+
+func TestFlattenLakeFormationPermissionsTableBlocks(t *testing.T) {
+	table := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionAlter}),
+		Resource: &lakeformation.Resource{
+			Table: &lakeformation.TableResource{
+				CatalogId:    aws.String("123456789012"),
+				DatabaseName: aws.String("db"),
+				Name:         aws.String("table"),
+			},
+		},
+	}
+	companion := &lakeformation.PrincipalResourcePermissions{
+		Permissions: aws.StringSlice([]string{lakeformation.PermissionSelect}),
+		Resource: &lakeformation.Resource{
+			TableWithColumns: &lakeformation.TableWithColumnsResource{
+				CatalogId:      aws.String("123456789012"),
+				ColumnWildcard: &lakeformation.ColumnWildcard{},
+				DatabaseName:   aws.String("db"),
+				Name:           aws.String("table"),
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name                   string
+		ResourceType           string
+		ApiObjects             []*lakeformation.PrincipalResourcePermissions
+		ExpectTable            bool
+		ExpectTableWithColumns bool
+	}{
+		{
+			Name:         "table SELECT grant with companion first",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{companion, table},
+			ExpectTable:  true,
+		},
+		{
+			Name:         "table SELECT grant with companion only",
+			ResourceType: lakeformation.DataLakeResourceTypeTable,
+			ApiObjects:   []*lakeformation.PrincipalResourcePermissions{companion},
+			ExpectTable:  true,
+		},
+		{
+			Name:                   "table with columns grant",
+			ResourceType:           DataLakeResourceTypeTableWithColumns,
+			ApiObjects:             []*lakeformation.PrincipalResourcePermissions{companion},
+			ExpectTableWithColumns: true,
+		},
+		{
+			Name:                   "table with columns grant with companion table first",
+			ResourceType:           DataLakeResourceTypeTableWithColumns,
+			ApiObjects:             []*lakeformation.PrincipalResourcePermissions{table, companion},
+			ExpectTableWithColumns: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tableBlock, tableWithColumnsBlock := flattenLakeFormationPermissionsTableBlocks(testCase.ResourceType, testCase.ApiObjects)
+
+			if tableBlock != nil && tableWithColumnsBlock != nil {
+				t.Fatal("expected at most one of table and table_with_columns to be populated")
+			}
+
+			if got := tableBlock != nil; got != testCase.ExpectTable {
+				t.Errorf("expected table populated %t, got %t", testCase.ExpectTable, got)
+			}
+
+			if got := tableWithColumnsBlock != nil; got != testCase.ExpectTableWithColumns {
+				t.Errorf("expected table_with_columns populated %t, got %t", testCase.ExpectTableWithColumns, got)
+			}
+		})
+	}
+}
+
+func TestLakeFormationCatalogIdEquivalent(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Old        string
+		New        string
+		Equivalent bool
+	}{
+		{
+			Name:       "both empty",
+			Equivalent: true,
+		},
+		{
+			Name:       "same account",
+			Old:        "123456789012",
+			New:        "123456789012",
+			Equivalent: true,
+		},
+		{
+			Name:       "unset in configuration and account ID in state",
+			Old:        "123456789012",
+			Equivalent: true,
+		},
+		{
+			Name:       "account ID configured and nothing in state",
+			New:        "123456789012",
+			Equivalent: true,
+		},
+		{
+			Name:       "unset in configuration and another account in state",
+			Old:        "111122223333",
+			Equivalent: false,
+		},
+		{
+			Name:       "another account configured and nothing in state",
+			New:        "111122223333",
+			Equivalent: false,
+		},
+		{
+			Name:       "different accounts",
+			Old:        "123456789012",
+			New:        "111122223333",
+			Equivalent: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := lakeFormationCatalogIdEquivalent(testCase.Old, testCase.New, "123456789012")
+
+			if got != testCase.Equivalent {
+				t.Errorf("got %t, expected %t", got, testCase.Equivalent)
+			}
+		})
+	}
+}
+
 func testAccAWSLakeFormationPermissions_basic(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_lakeformation_permissions.test"
@@ -29,14 +3978,222 @@ func testAccAWSLakeFormationPermissions_basic(t *testing.T) {
 					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
 					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", lakeformation.PermissionCreateDatabase),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionCreateDatabase),
 					resource.TestCheckResourceAttr(resourceName, "catalog_resource", "true"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationPermissions_catalogRevokeGuard(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_catalogRevokeGuard(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "allow_catalog_revoke", "false"),
+				),
+			},
+			{
+				Config:      testAccAWSLakeFormationPermissionsConfig_catalogRevokeGuard(rName),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`set allow_catalog_revoke to true`),
+			},
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "allow_catalog_revoke", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationPermissions_validateOnly(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_validateOnly(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsNotGranted(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "validate_only", "true"),
+					resource.TestCheckResourceAttr(resourceName, "feasibility_issues.#", "0"),
+				),
+			},
 		},
 	})
 }
 
+// testAccAWSLakeFormationPermissions_matrix runs the same create, read and destroy cycle for every resource
+// type and a set of permission combinations to lock in reconciliation behavior.
+func testAccAWSLakeFormationPermissions_matrix(t *testing.T) {
+	testCases := []struct {
+		Name                       string
+		Block                      string
+		Target                     string
+		Permissions                []string
+		PermissionsWithGrantOption []string
+	}{
+		{
+			Name:        "catalog",
+			Block:       "catalog_resource",
+			Target:      "allow_catalog_revoke = true\n  catalog_resource     = true",
+			Permissions: []string{lakeformation.PermissionCreateDatabase},
+		},
+		{
+			Name:                       "catalogGrantOption",
+			Block:                      "catalog_resource",
+			Target:                     "allow_catalog_revoke = true\n  catalog_resource     = true",
+			Permissions:                []string{lakeformation.PermissionCreateDatabase},
+			PermissionsWithGrantOption: []string{lakeformation.PermissionCreateDatabase},
+		},
+		{
+			Name:  "dataLocation",
+			Block: "data_location",
+			Target: `data_location {
+    arn = aws_lakeformation_resource.test.arn
+  }`,
+			Permissions: []string{lakeformation.PermissionDataLocationAccess},
+		},
+		{
+			Name:  "database",
+			Block: "database",
+			Target: `database {
+    name = aws_glue_catalog_database.test.name
+  }`,
+			Permissions:                []string{lakeformation.PermissionAlter, lakeformation.PermissionCreateTable, lakeformation.PermissionDrop},
+			PermissionsWithGrantOption: []string{lakeformation.PermissionCreateTable},
+		},
+		{
+			Name:  "table",
+			Block: "table",
+			Target: `table {
+    database_name = aws_glue_catalog_table.test.database_name
+    name          = aws_glue_catalog_table.test.name
+  }`,
+			Permissions: []string{lakeformation.PermissionAlter, lakeformation.PermissionDelete, lakeformation.PermissionDescribe},
+		},
+		{
+			Name:  "tableSelect",
+			Block: "table",
+			Target: `table {
+    database_name = aws_glue_catalog_table.test.database_name
+    name          = aws_glue_catalog_table.test.name
+  }`,
+			Permissions: []string{lakeformation.PermissionSelect},
+		},
+		{
+			Name:  "tableAll",
+			Block: "table",
+			Target: `table {
+    database_name = aws_glue_catalog_table.test.database_name
+    name          = aws_glue_catalog_table.test.name
+  }`,
+			Permissions: []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:  "tableWildcard",
+			Block: "table",
+			Target: `table {
+    database_name = aws_glue_catalog_database.test.name
+    wildcard      = true
+  }`,
+			Permissions: []string{lakeformation.PermissionAll},
+		},
+		{
+			Name:  "tableWithColumns",
+			Block: "table_with_columns",
+			Target: `table_with_columns {
+    database_name = aws_glue_catalog_table.test.database_name
+    name          = aws_glue_catalog_table.test.name
+    column_names  = ["event", "timestamp"]
+  }`,
+			Permissions: []string{lakeformation.PermissionSelect},
+		},
+		{
+			Name:  "tableWithColumnsExcluded",
+			Block: "table_with_columns",
+			Target: `table_with_columns {
+    database_name         = aws_glue_catalog_table.test.database_name
+    name                  = aws_glue_catalog_table.test.name
+    excluded_column_names = ["value"]
+  }`,
+			Permissions: []string{lakeformation.PermissionSelect},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			rName := acctest.RandomWithPrefix("tf-acc-test")
+			resourceName := "aws_lakeformation_permissions.test"
+
+			checks := []resource.TestCheckFunc{
+				testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+				resource.TestCheckResourceAttrPair(resourceName, "principal", "aws_iam_role.test", "arn"),
+				resource.TestCheckResourceAttr(resourceName, "permissions.#", fmt.Sprintf("%d", len(testCase.Permissions))),
+				resource.TestCheckResourceAttr(resourceName, "permissions_with_grant_option.#", fmt.Sprintf("%d", len(testCase.PermissionsWithGrantOption))),
+			}
+
+			for _, permission := range testCase.Permissions {
+				checks = append(checks, resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", permission))
+			}
+
+			for _, permission := range testCase.PermissionsWithGrantOption {
+				checks = append(checks, resource.TestCheckTypeSetElemAttr(resourceName, "permissions_with_grant_option.*", permission))
+			}
+
+			for _, block := range []string{"data_location", "database", "table", "table_with_columns"} {
+				expected := "0"
+				if block == testCase.Block {
+					expected = "1"
+				}
+				checks = append(checks, resource.TestCheckResourceAttr(resourceName, block+".#", expected))
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+				ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+				Providers:    testAccProviders,
+				CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccAWSLakeFormationPermissionsConfig_matrix(rName, testCase.Target, testCase.Permissions, testCase.PermissionsWithGrantOption),
+						Check:  resource.ComposeTestCheckFunc(checks...),
+					},
+					{
+						// A second plan must be empty for every resource type.
+						Config:   testAccAWSLakeFormationPermissionsConfig_matrix(rName, testCase.Target, testCase.Permissions, testCase.PermissionsWithGrantOption),
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+	}
+}
+
 func testAccAWSLakeFormationPermissions_dataLocation(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_lakeformation_permissions.test"
@@ -55,7 +4212,7 @@ func testAccAWSLakeFormationPermissions_dataLocation(t *testing.T) {
 					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
 					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", lakeformation.PermissionDataLocationAccess),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionDataLocationAccess),
 					resource.TestCheckResourceAttr(resourceName, "catalog_resource", "false"),
 					resource.TestCheckResourceAttr(resourceName, "data_location.#", "1"),
 					resource.TestCheckResourceAttrPair(resourceName, "data_location.0.arn", bucketName, "arn"),
@@ -65,6 +4222,98 @@ func testAccAWSLakeFormationPermissions_dataLocation(t *testing.T) {
 	})
 }
 
+func testAccAWSLakeFormationPermissions_dataLocationRegister(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_permissions.test"
+	bucketName := "aws_s3_bucket.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_dataLocationRegister(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "register_data_location", "true"),
+					resource.TestCheckResourceAttr(resourceName, "data_location.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "data_location.0.arn", bucketName, "arn"),
+					testAccCheckAWSLakeFormationDataLocationRegistered(bucketName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSLakeFormationDataLocationRegistered(bucketName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[bucketName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", bucketName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+		_, err := conn.DescribeResource(&lakeformation.DescribeResourceInput{
+			ResourceArn: aws.String(rs.Primary.Attributes["arn"]),
+		})
+
+		return err
+	}
+}
+
+func testAccAWSLakeFormationPermissions_databases(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_permissions.test"
+	roleName := "aws_iam_role.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_databases(rName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "database.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "databases.*", map[string]string{"name": rName + "-0"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "databases.*", map[string]string{"name": rName + "-1"}),
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionAlter),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionCreateTable),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_databases(rName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "databases.*", map[string]string{"name": rName + "-2"}),
+				),
+			},
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_databases(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "databases.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "databases.*", map[string]string{"name": rName + "-0"}),
+				),
+			},
+		},
+	})
+}
+
 func testAccAWSLakeFormationPermissions_database(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 	resourceName := "aws_lakeformation_permissions.test"
@@ -87,11 +4336,40 @@ func testAccAWSLakeFormationPermissions_database(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "database.#", "1"),
 					resource.TestCheckResourceAttrPair(resourceName, "database.0.name", dbName, "name"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "3"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", "ALTER"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.1", lakeformation.PermissionCreateTable),
-					resource.TestCheckResourceAttr(resourceName, "permissions.2", lakeformation.PermissionDrop),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", "ALTER"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionCreateTable),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionDrop),
 					resource.TestCheckResourceAttr(resourceName, "permissions_with_grant_option.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "permissions_with_grant_option.0", lakeformation.PermissionCreateTable),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions_with_grant_option.*", lakeformation.PermissionCreateTable),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSLakeFormationPermissions_principalPathChange(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_lakeformation_permissions.test"
+	roleName := "aws_iam_role.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPartitionHasServicePreCheck(lakeformation.EndpointsID, t) },
+		ErrorCheck:   testAccErrorCheck(t, lakeformation.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSLakeFormationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_principalPath(rName, "/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
+				),
+			},
+			{
+				Config: testAccAWSLakeFormationPermissionsConfig_principalPath(rName, "/moved/"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSLakeFormationPermissionsExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "principal", roleName, "arn"),
 				),
 			},
 		},
@@ -119,9 +4397,9 @@ func testAccAWSLakeFormationPermissions_table_name(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName, "table.0.database_name", tableName, "database_name"),
 					resource.TestCheckResourceAttrPair(resourceName, "table.0.name", tableName, "name"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "3"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", lakeformation.PermissionAlter),
-					resource.TestCheckResourceAttr(resourceName, "permissions.1", lakeformation.PermissionDelete),
-					resource.TestCheckResourceAttr(resourceName, "permissions.2", lakeformation.PermissionDescribe),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionAlter),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionDelete),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionDescribe),
 				),
 			},
 		},
@@ -176,9 +4454,14 @@ func testAccAWSLakeFormationPermissions_tableWithColumns(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "table_with_columns.0.column_names.0", "event"),
 					resource.TestCheckResourceAttr(resourceName, "table_with_columns.0.column_names.1", "timestamp"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", lakeformation.PermissionSelect),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionSelect),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -207,7 +4490,7 @@ func testAccAWSLakeFormationPermissions_tableWithColumnsAndTable(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "table_with_columns.0.column_names.0", "event"),
 					resource.TestCheckResourceAttr(resourceName, "table_with_columns.0.column_names.1", "timestamp"),
 					resource.TestCheckResourceAttr(resourceName, "permissions.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "permissions.0", lakeformation.PermissionSelect),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", lakeformation.PermissionSelect),
 				),
 			},
 		},
@@ -388,7 +4671,83 @@ func testAccCheckAWSLakeFormationPermissionsExists(resourceName string) resource
 	}
 }
 
-func testAccAWSLakeFormationPermissionsConfig_basic(rName string) string {
+// testAccCheckAWSLakeFormationPermissionsNotGranted checks that the principal holds no permissions on the database
+// of a validate_only resource.
+func testAccCheckAWSLakeFormationPermissionsNotGranted(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).lakeformationconn
+
+		input := &lakeformation.ListPermissionsInput{
+			Principal: &lakeformation.DataLakePrincipal{
+				DataLakePrincipalIdentifier: aws.String(rs.Primary.Attributes["principal"]),
+			},
+			Resource: &lakeformation.Resource{
+				Database: &lakeformation.DatabaseResource{
+					Name: aws.String(rs.Primary.Attributes["database.0.name"]),
+				},
+			},
+			ResourceType: aws.String(lakeformation.DataLakeResourceTypeDatabase),
+		}
+
+		output, err := conn.ListPermissions(input)
+
+		if err != nil {
+			return fmt.Errorf("unable to get Lake Formation permissions (%s): %w", rs.Primary.ID, err)
+		}
+
+		if len(output.PrincipalResourcePermissions) > 0 {
+			return fmt.Errorf("Lake Formation permissions (%s) granted: %v", rs.Primary.ID, output.PrincipalResourcePermissions)
+		}
+
+		return nil
+	}
+}
+
+func testAccAWSLakeFormationPermissionsConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "lakeformation.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  principal            = aws_iam_role.test.arn
+  permissions          = ["CREATE_DATABASE"]
+  catalog_resource     = true
+  allow_catalog_revoke = true
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationPermissionsConfig_catalogRevokeGuard(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
 
@@ -478,6 +4837,144 @@ resource "aws_lakeformation_permissions" "test" {
 `, rName)
 }
 
+func testAccAWSLakeFormationPermissionsConfig_dataLocationRegister(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_s3_bucket" "test" {
+  bucket        = %[1]q
+  acl           = "private"
+  force_destroy = true
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  principal              = aws_iam_role.test.arn
+  permissions            = ["DATA_LOCATION_ACCESS"]
+  register_data_location = true
+
+  data_location {
+    arn = aws_s3_bucket.test.arn
+  }
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationPermissionsConfig_matrix(rName, target string, permissions, permissionsWithGrantOption []string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_s3_bucket" "test" {
+  bucket        = %[1]q
+  acl           = "private"
+  force_destroy = true
+}
+
+resource "aws_lakeformation_resource" "test" {
+  arn = aws_s3_bucket.test.arn
+}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_glue_catalog_table" "test" {
+  name          = %[1]q
+  database_name = aws_glue_catalog_database.test.name
+
+  storage_descriptor {
+    columns {
+      name = "event"
+      type = "string"
+    }
+    columns {
+      name = "timestamp"
+      type = "date"
+    }
+    columns {
+      name = "value"
+      type = "double"
+    }
+  }
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  permissions                   = [%[3]s]
+  permissions_with_grant_option = [%[4]s]
+  principal                     = aws_iam_role.test.arn
+
+  %[2]s
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName, target, testAccAWSLakeFormationPermissionsConfigQuotedList(permissions), testAccAWSLakeFormationPermissionsConfigQuotedList(permissionsWithGrantOption))
+}
+
+func testAccAWSLakeFormationPermissionsConfigQuotedList(values []string) string {
+	quoted := make([]string, 0, len(values))
+
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
 func testAccAWSLakeFormationPermissionsConfig_database(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
@@ -527,6 +5024,157 @@ resource "aws_lakeformation_permissions" "test" {
 `, rName)
 }
 
+func testAccAWSLakeFormationPermissionsConfig_databases(rName string, count int) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  count = 3
+
+  name = "%[1]s-${count.index}"
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  permissions = ["ALTER", "CREATE_TABLE"]
+  principal   = aws_iam_role.test.arn
+
+  dynamic "databases" {
+    for_each = slice(aws_glue_catalog_database.test[*].name, 0, %[2]d)
+
+    content {
+      name = databases.value
+    }
+  }
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName, count)
+}
+
+func testAccAWSLakeFormationPermissionsConfig_validateOnly(rName string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = "/"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  permissions   = ["ALTER", "CREATE_TABLE", "DROP"]
+  principal     = aws_iam_role.test.arn
+  validate_only = true
+
+  database {
+    name = aws_glue_catalog_database.test.name
+  }
+
+  depends_on = ["aws_lakeformation_data_lake_settings.test"]
+}
+`, rName)
+}
+
+func testAccAWSLakeFormationPermissionsConfig_principalPath(rName, path string) string {
+	return fmt.Sprintf(`
+data "aws_partition" "current" {}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+  path = %[2]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "glue.${data.aws_partition.current.dns_suffix}"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_glue_catalog_database" "test" {
+  name = %[1]q
+}
+
+resource "aws_lakeformation_data_lake_settings" "test" {
+  admins = [data.aws_caller_identity.current.arn]
+}
+
+resource "aws_lakeformation_permissions" "test" {
+  permissions = ["ALTER", "CREATE_TABLE", "DROP"]
+  principal   = aws_iam_role.test.arn
+
+  database {
+    name = aws_glue_catalog_database.test.name
+  }
+
+  depends_on = [aws_lakeformation_data_lake_settings.test]
+}
+`, rName, path)
+}
+
 func testAccAWSLakeFormationPermissionsConfig_table_name(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/lakeformation"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/service/lakeformation/waiter"
 )
 
 func resourceAwsLakeFormationResource() *schema.Resource {
@@ -28,6 +29,10 @@ func resourceAwsLakeFormationResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"ready": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"role_arn": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -62,6 +67,11 @@ func resourceAwsLakeFormationResourceCreate(d *schema.ResourceData, meta interfa
 	}
 
 	d.SetId(resourceArn)
+
+	if _, err := waiter.ResourceRegistered(conn, resourceArn); err != nil {
+		return fmt.Errorf("error waiting for Lake Formation Resource (%s) registration: %w", resourceArn, err)
+	}
+
 	return resourceAwsLakeFormationResourceRead(d, meta)
 }
 
@@ -90,6 +100,7 @@ func resourceAwsLakeFormationResourceRead(d *schema.ResourceData, meta interface
 	}
 
 	// d.Set("arn", output.ResourceInfo.ResourceArn) // output not including resource arn currently
+	d.Set("ready", true)
 	d.Set("role_arn", output.ResourceInfo.RoleArn)
 	if output.ResourceInfo.LastModified != nil { // output not including last modified currently
 		d.Set("last_modified", output.ResourceInfo.LastModified.Format(time.RFC3339))
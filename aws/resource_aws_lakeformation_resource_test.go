@@ -30,6 +30,7 @@ func TestAccAWSLakeFormationResource_basic(t *testing.T) {
 					testAccCheckAWSLakeFormationResourceExists(resourceAddr),
 					resource.TestCheckResourceAttrPair(resourceAddr, "role_arn", roleAddr, "arn"),
 					resource.TestCheckResourceAttrPair(resourceAddr, "arn", bucketAddr, "arn"),
+					resource.TestCheckResourceAttr(resourceAddr, "ready", "true"),
 				),
 			},
 		},
@@ -6,17 +6,33 @@ import (
 
 func TestAccAWSLakeFormation_serial(t *testing.T) {
 	testCases := map[string]map[string]func(t *testing.T){
+		"BatchPermissions": {
+			"basic":             testAccAWSLakeFormationBatchPermissions_basic,
+			"multipleResources": testAccAWSLakeFormationBatchPermissions_multipleResources,
+			"update":            testAccAWSLakeFormationBatchPermissions_update,
+		},
+		"DatabaseDefaultPermissions": {
+			"basic":                  testAccAWSLakeFormationDatabaseDefaultPermissions_basic,
+			"dataLakeSettingsUpdate": testAccAWSLakeFormationDatabaseDefaultPermissions_dataLakeSettingsUpdate,
+		},
 		"DataLakeSettings": {
 			"basic":            testAccAWSLakeFormationDataLakeSettings_basic,
 			"disappears":       testAccAWSLakeFormationDataLakeSettings_disappears,
+			"forceDestroy":     testAccAWSLakeFormationDataLakeSettings_forceDestroy,
 			"withoutCatalogId": testAccAWSLakeFormationDataLakeSettings_withoutCatalogId,
 			"dataSource":       testAccAWSLakeFormationDataLakeSettingsDataSource_basic,
 		},
 		"Permissions": {
-			"basic":             testAccAWSLakeFormationPermissions_basic,
-			"dataLocation":      testAccAWSLakeFormationPermissions_dataLocation,
-			"database":          testAccAWSLakeFormationPermissions_database,
-			"selectPermissions": testAccAWSLakeFormationPermissions_selectPermissions,
+			"basic":                testAccAWSLakeFormationPermissions_basic,
+			"catalogRevokeGuard":   testAccAWSLakeFormationPermissions_catalogRevokeGuard,
+			"dataLocation":         testAccAWSLakeFormationPermissions_dataLocation,
+			"dataLocationRegister": testAccAWSLakeFormationPermissions_dataLocationRegister,
+			"database":             testAccAWSLakeFormationPermissions_database,
+			"databases":            testAccAWSLakeFormationPermissions_databases,
+			"matrix":               testAccAWSLakeFormationPermissions_matrix,
+			"principalPathChange":  testAccAWSLakeFormationPermissions_principalPathChange,
+			"selectPermissions":    testAccAWSLakeFormationPermissions_selectPermissions,
+			"validateOnly":         testAccAWSLakeFormationPermissions_validateOnly,
 		},
 		"TablePermissions": {
 			"tableName":                testAccAWSLakeFormationPermissions_table_name,
@@ -28,8 +44,11 @@ func TestAccAWSLakeFormation_serial(t *testing.T) {
 			"basicDataSource":            testAccAWSLakeFormationPermissionsDataSource_basic,
 			"dataLocationDataSource":     testAccAWSLakeFormationPermissionsDataSource_dataLocation,
 			"databaseDataSource":         testAccAWSLakeFormationPermissionsDataSource_database,
+			"noPermissionsDataSource":    testAccAWSLakeFormationPermissionsDataSource_noPermissions,
 			"tableDataSource":            testAccAWSLakeFormationPermissionsDataSource_table,
+			"tableSelectDataSource":      testAccAWSLakeFormationPermissionsDataSource_tableSelect,
 			"tableWithColumnsDataSource": testAccAWSLakeFormationPermissionsDataSource_tableWithColumns,
+			"principalDataSource":        testAccAWSLakeFormationPrincipalPermissionsDataSource_basic,
 		},
 	}
 
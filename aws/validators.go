@@ -685,9 +685,9 @@ func validatePrincipal(v interface{}, k string) (ws []string, errors []error) {
 	ws = append(ws, wsARN...)
 	errors = append(errors, errorsARN...)
 
-	pattern := `:(role|user|group|ou|organization)/`
+	pattern := `:(role|assumed-role|user|group|ou|organization)/`
 	if !regexp.MustCompile(pattern).MatchString(value) {
-		errors = append(errors, fmt.Errorf("%q does not look like a user, role, group, OU, or organization: %q", k, value))
+		errors = append(errors, fmt.Errorf("%q does not look like a user, role, assumed role, group, OU, or organization: %q", k, value))
 	}
 
 	if len(errors) > 0 {